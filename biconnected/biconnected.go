@@ -0,0 +1,236 @@
+// Package biconnected finds the single points of failure of undirected
+// graphs: the bridges whose removal disconnects the graph, the articulation
+// points whose removal does the same, and the components that survive the
+// loss of any one edge or vertex.
+package biconnected
+
+import (
+	"github.com/aybabtme/graph"
+	"sort"
+)
+
+// BCC holds the biconnectivity of an undirected graph.  Like path.CC,
+// Count, ID and Connected partition the vertices, here into 2-edge-connected
+// components, that stay connected after removing any one edge.  Blocks are
+// the biconnected components, that stay connected after removing any one
+// vertex; they partition the edges, and articulation points belong to more
+// than one of them.
+type BCC interface {
+	// Connected tells whether vertices v and w are 2-edge-connected
+	Connected(v, w int) bool
+	// Count is the number of 2-edge-connected components in the graph
+	Count() int
+	// ID of the 2-edge-connected component containing vertex v
+	ID(v int) int
+	// IsBridge tells if an edge between v and w disconnects the graph when
+	// removed.  Parallel edges are never bridges.
+	IsBridge(v, w int) bool
+	// Bridges are the edges that are bridges, as pairs of vertices in
+	// increasing order
+	Bridges() [][2]int
+	// IsArticulation tells if vertex v disconnects the graph when removed
+	IsArticulation(v int) bool
+	// Articulations are the articulation points, in increasing order
+	Articulations() []int
+	// Blocks are the vertices of each biconnected component.  Vertices
+	// without edges aren't part of any block, and self-loops are ignored.
+	Blocks() [][]int
+}
+
+type bcc struct {
+	id           []int
+	count        int
+	bridges      map[[2]int]bool
+	articulation []bool
+	blocks       [][]int
+}
+
+type frame struct {
+	v             int
+	parent        int
+	next          int
+	skippedParent bool
+}
+
+// BuildBCC builds the biconnectivity of graph g with a single depth-first
+// search, using Tarjan's low-link values.  This is O(E + V).
+func BuildBCC(g graph.Ungraph) BCC {
+	b := &bcc{
+		id:           make([]int, g.V()),
+		bridges:      make(map[[2]int]bool),
+		articulation: make([]bool, g.V()),
+	}
+
+	pre := make([]int, g.V())
+	low := make([]int, g.V())
+	children := make([]int, g.V())
+	for v := range pre {
+		pre[v] = -1
+	}
+
+	// inBlock[v] is the last block v was added to, plus one
+	inBlock := make([]int, g.V())
+
+	var (
+		preCount int
+		vertices []int
+		edges    [][2]int
+		stack    []frame
+	)
+
+	for s := 0; s < g.V(); s++ {
+		if pre[s] != -1 {
+			continue
+		}
+		pre[s], low[s] = preCount, preCount
+		preCount++
+		vertices = append(vertices, s)
+		stack = append(stack, frame{v: s, parent: -1})
+
+		for len(stack) != 0 {
+			f := &stack[len(stack)-1]
+			v := f.v
+			adj := g.Adj(v)
+
+			if f.next < len(adj) {
+				w := adj[f.next]
+				f.next++
+
+				switch {
+				case w == f.parent && !f.skippedParent:
+					// the tree edge we came from, but only once, since a
+					// parallel edge back to the parent is a back edge
+					f.skippedParent = true
+				case pre[w] == -1:
+					children[v]++
+					pre[w], low[w] = preCount, preCount
+					preCount++
+					vertices = append(vertices, w)
+					edges = append(edges, [2]int{v, w})
+					stack = append(stack, frame{v: w, parent: v})
+				case pre[w] < pre[v]:
+					// back edge to an ancestor, self-loops are skipped
+					if pre[w] < low[v] {
+						low[v] = pre[w]
+					}
+					edges = append(edges, [2]int{v, w})
+				}
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			p := f.parent
+			if p == -1 {
+				if children[v] > 1 {
+					b.articulation[v] = true
+				}
+				b.popComponent(&vertices, v)
+				continue
+			}
+
+			if low[v] < low[p] {
+				low[p] = low[v]
+			}
+
+			if low[v] >= pre[p] {
+				// p separates v's subtree from the rest, whose edges are
+				// on the stack above the tree edge p-v
+				if stack[len(stack)-1].parent != -1 {
+					// roots are handled when they're done
+					b.articulation[p] = true
+				}
+				var block []int
+				for {
+					e := edges[len(edges)-1]
+					edges = edges[:len(edges)-1]
+					for _, x := range e {
+						if inBlock[x] != len(b.blocks)+1 {
+							inBlock[x] = len(b.blocks) + 1
+							block = append(block, x)
+						}
+					}
+					if e == [2]int{p, v} {
+						break
+					}
+				}
+				b.blocks = append(b.blocks, block)
+			}
+
+			if low[v] > pre[p] {
+				b.bridges[edgeKey(p, v)] = true
+				b.popComponent(&vertices, v)
+			}
+		}
+	}
+
+	return b
+}
+
+// popComponent assigns every vertex on the stack down to v to a new
+// 2-edge-connected component.
+func (b *bcc) popComponent(vertices *[]int, v int) {
+	for {
+		w := (*vertices)[len(*vertices)-1]
+		*vertices = (*vertices)[:len(*vertices)-1]
+		b.id[w] = b.count
+		if w == v {
+			break
+		}
+	}
+	b.count++
+}
+
+func edgeKey(v, w int) [2]int {
+	if v > w {
+		return [2]int{w, v}
+	}
+	return [2]int{v, w}
+}
+
+func (b *bcc) Connected(v, w int) bool {
+	return b.id[v] == b.id[w]
+}
+
+func (b *bcc) Count() int {
+	return b.count
+}
+
+func (b *bcc) ID(v int) int {
+	return b.id[v]
+}
+
+func (b *bcc) IsBridge(v, w int) bool {
+	return b.bridges[edgeKey(v, w)]
+}
+
+func (b *bcc) Bridges() [][2]int {
+	var bridges [][2]int
+	for e := range b.bridges {
+		bridges = append(bridges, e)
+	}
+	sort.Slice(bridges, func(i, j int) bool {
+		if bridges[i][0] != bridges[j][0] {
+			return bridges[i][0] < bridges[j][0]
+		}
+		return bridges[i][1] < bridges[j][1]
+	})
+	return bridges
+}
+
+func (b *bcc) IsArticulation(v int) bool {
+	return b.articulation[v]
+}
+
+func (b *bcc) Articulations() []int {
+	var points []int
+	for v, is := range b.articulation {
+		if is {
+			points = append(points, v)
+		}
+	}
+	return points
+}
+
+func (b *bcc) Blocks() [][]int {
+	return b.blocks
+}
@@ -0,0 +1,164 @@
+package biconnected
+
+import (
+	"github.com/aybabtme/graph"
+	"github.com/aybabtme/graph/path"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type edgeList []struct{ from, to int }
+
+// This graph has two triangles joined by the bridge 2-3, and the pendant
+// edge 5-6 hanging off the second triangle.  7 is alone.
+//
+//  0---1       3---4
+//   \ /       / \ /
+//    2-------+   5---6
+//
+var bridgedEdges = edgeList{
+	{0, 1}, {1, 2}, {2, 0},
+	{2, 3},
+	{3, 4}, {4, 5}, {5, 3},
+	{5, 6},
+}
+
+func buildGraph(v int, edges edgeList) graph.Ungraph {
+	g := graph.NewGraph(v)
+	for _, e := range edges {
+		g.AddEdge(e.from, e.to)
+	}
+	return g
+}
+
+func TestBCCMatchesKnownOutput(t *testing.T) {
+	g := buildGraph(8, bridgedEdges)
+	b := BuildBCC(g)
+
+	if b.Count() != 4 {
+		t.Errorf("Expected 4 2-edge-connected components, got %d", b.Count())
+	}
+	for _, comp := range [][]int{{0, 1, 2}, {3, 4, 5}} {
+		for _, v := range comp {
+			if !b.Connected(comp[0], v) || b.ID(comp[0]) != b.ID(v) {
+				t.Errorf("%d and %d should be 2-edge-connected", comp[0], v)
+			}
+		}
+	}
+	if b.Connected(2, 3) || b.Connected(5, 6) || b.Connected(6, 7) {
+		t.Errorf("Bridged vertices shouldn't be 2-edge-connected")
+	}
+
+	wantBridges := [][2]int{{2, 3}, {5, 6}}
+	gotBridges := b.Bridges()
+	if len(gotBridges) != len(wantBridges) {
+		t.Fatalf("Expected bridges %v, got %v", wantBridges, gotBridges)
+	}
+	for i := range wantBridges {
+		if wantBridges[i] != gotBridges[i] {
+			t.Errorf("Expected bridges %v, got %v", wantBridges, gotBridges)
+		}
+	}
+	if !b.IsBridge(3, 2) || b.IsBridge(0, 1) {
+		t.Errorf("Only 2-3 and 5-6 should be bridges")
+	}
+
+	compareInts(t, []int{2, 3, 5}, b.Articulations(), "Articulations")
+	if b.IsArticulation(0) || !b.IsArticulation(5) {
+		t.Errorf("5 should be the only articulation point among 0 and 5")
+	}
+
+	blocks := b.Blocks()
+	if len(blocks) != 4 {
+		t.Fatalf("Expected 4 blocks, got %v", blocks)
+	}
+	for _, block := range blocks {
+		sort.Ints(block)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i][0] < blocks[j][0] })
+	for i, want := range [][]int{{0, 1, 2}, {2, 3}, {3, 4, 5}, {5, 6}} {
+		compareInts(t, want, blocks[i], "Block")
+	}
+}
+
+func TestBCCParallelEdgesAreNotBridges(t *testing.T) {
+	g := buildGraph(3, edgeList{{0, 1}, {1, 0}, {1, 2}, {2, 2}})
+	b := BuildBCC(g)
+
+	if b.IsBridge(0, 1) {
+		t.Errorf("Parallel edges 0-1 shouldn't be bridges")
+	}
+	if !b.IsBridge(1, 2) {
+		t.Errorf("1-2 should be a bridge, self-loop on 2 notwithstanding")
+	}
+	compareInts(t, []int{1}, b.Articulations(), "Articulations")
+}
+
+func TestBCCMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 100; i++ {
+		v := 1 + r.Intn(15)
+		var edges edgeList
+		for e := r.Intn(2 * v); e > 0; e-- {
+			edges = append(edges, struct{ from, to int }{r.Intn(v), r.Intn(v)})
+		}
+		g := buildGraph(v, edges)
+		b := BuildBCC(g)
+		components := path.BuildCC(g).Count()
+
+		for i, e := range edges {
+			without := append(append(edgeList{}, edges[:i]...), edges[i+1:]...)
+			isBridge := path.BuildCC(buildGraph(v, without)).Count() > components
+			if isBridge != b.IsBridge(e.from, e.to) {
+				t.Fatalf("Edge %d-%d should be a bridge=%v in %v", e.from, e.to, isBridge, edges)
+			}
+		}
+
+		for x := 0; x < v; x++ {
+			// removing x leaves it alone, so it counts as one more
+			// component than the ones it splits
+			var without edgeList
+			for _, e := range edges {
+				if e.from != x && e.to != x {
+					without = append(without, e)
+				}
+			}
+			isArticulation := path.BuildCC(buildGraph(v, without)).Count() > components+1
+			if isArticulation != b.IsArticulation(x) {
+				t.Fatalf("Vertex %d should be articulation=%v in %v", x, isArticulation, edges)
+			}
+		}
+
+		for x := 0; x < v; x++ {
+			for y := 0; y < v; y++ {
+				// 2-edge-connected vertices are connected without any of
+				// the bridges
+				var without edgeList
+				for _, e := range edges {
+					if !b.IsBridge(e.from, e.to) {
+						without = append(without, e)
+					}
+				}
+				connected := path.BuildCC(buildGraph(v, without)).Connected(x, y)
+				if connected != b.Connected(x, y) {
+					t.Fatalf("Vertices %d and %d should be 2-edge-connected=%v in %v",
+						x, y, connected, edges)
+				}
+			}
+		}
+	}
+}
+
+func compareInts(t *testing.T, want, got []int, what string) {
+	if len(want) != len(got) {
+		t.Errorf("%s, want %v got %v", what, want, got)
+		return
+	}
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("%s, want %v got %v", what, want, got)
+			return
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package graph
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"strconv"
+	"unsafe"
+)
+
+// The binary graph format stores a graph in compressed sparse row form, as
+// CSRGraph does, so that it can be mapped in memory and used without
+// parsing.  All numbers are little endian:
+//   header     32 bytes
+//     magic    4 bytes, "GRPH"
+//     version  uint16, binaryVersion
+//     flags    uint16, binaryDirected and binaryWeighted
+//     V        uint64, the vertex count
+//     E        uint64, the edge count
+//     A        uint64, the length of all the adjacency lists together
+//   offsets    V+1 int64, where the adjacency list of each vertex starts
+//   adjacency  A int64, the adjacency lists of all the vertices
+//   weights    A float64, the weight of each edge in adjacency, only if
+//              binaryWeighted is set
+//   checksum   uint32, the CRC-32 (Castagnoli) of everything before it
+
+const (
+	binaryMagic      = "GRPH"
+	binaryVersion    = 1
+	binaryHeaderSize = 32
+
+	binaryDirected = 1 << 0
+	binaryWeighted = 1 << 1
+)
+
+var binaryTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrBadChecksum is returned when reading a binary graph whose content
+// doesn't match its checksum.
+var ErrBadChecksum = errors.New("graph: binary graph checksum mismatch")
+
+// binaryWriter writes the sections of a binary graph, computing their
+// checksum and remembering the first error it encounters.
+type binaryWriter struct {
+	stickyWriter
+	crc uint32
+	buf [8]byte
+}
+
+func (b *binaryWriter) write(p []byte) {
+	b.crc = crc32.Update(b.crc, binaryTable, p)
+	b.stickyWriter.write(p)
+}
+
+func (b *binaryWriter) uint64(x uint64) {
+	binary.LittleEndian.PutUint64(b.buf[:], x)
+	b.write(b.buf[:])
+}
+
+func (b *binaryWriter) header(flags uint16, v, e, a int) {
+	var h [binaryHeaderSize]byte
+	copy(h[:], binaryMagic)
+	binary.LittleEndian.PutUint16(h[4:], binaryVersion)
+	binary.LittleEndian.PutUint16(h[6:], flags)
+	binary.LittleEndian.PutUint64(h[8:], uint64(v))
+	binary.LittleEndian.PutUint64(h[16:], uint64(e))
+	binary.LittleEndian.PutUint64(h[24:], uint64(a))
+	b.write(h[:])
+}
+
+func (b *binaryWriter) close() error {
+	binary.LittleEndian.PutUint32(b.buf[:], b.crc)
+	b.stickyWriter.write(b.buf[:4])
+	return b.flush()
+}
+
+// WriteBinaryGraph writes graph g to w in the binary graph format, which
+// OpenMappedGraph reads.  If g is a Digraph, the binary graph is directed.
+func WriteBinaryGraph(w io.Writer, g Graph) error {
+	var flags uint16
+	if isDirected(g) {
+		flags |= binaryDirected
+	}
+
+	a := 0
+	for v := 0; v < g.V(); v++ {
+		a += len(g.Adj(v))
+	}
+
+	b := &binaryWriter{stickyWriter: newStickyWriter(w)}
+	b.header(flags, g.V(), g.E(), a)
+	offset := 0
+	b.uint64(0)
+	for v := 0; v < g.V(); v++ {
+		offset += len(g.Adj(v))
+		b.uint64(uint64(offset))
+	}
+	for v := 0; v < g.V(); v++ {
+		for _, adj := range g.Adj(v) {
+			b.uint64(uint64(adj))
+		}
+	}
+	return b.close()
+}
+
+// WriteBinaryWeightGraph writes weighted graph wg to w in the binary graph
+// format, with its weights, which OpenMappedGraph reads.
+func WriteBinaryWeightGraph(w io.Writer, wg *WeightGraph) error {
+	a := 0
+	for v := 0; v < wg.V(); v++ {
+		a += len(wg.Adj(v))
+	}
+
+	b := &binaryWriter{stickyWriter: newStickyWriter(w)}
+	b.header(binaryWeighted, wg.V(), wg.E(), a)
+	offset := 0
+	b.uint64(0)
+	for v := 0; v < wg.V(); v++ {
+		offset += len(wg.Adj(v))
+		b.uint64(uint64(offset))
+	}
+	for v := 0; v < wg.V(); v++ {
+		for _, e := range wg.Adj(v) {
+			b.uint64(uint64(e.Other(v)))
+		}
+	}
+	for v := 0; v < wg.V(); v++ {
+		for _, e := range wg.Adj(v) {
+			b.uint64(math.Float64bits(e.Weight()))
+		}
+	}
+	return b.close()
+}
+
+// MappedGraph is an immutable graph read from a file in the binary graph
+// format, which it maps in memory instead of reading it where the platform
+// allows.  Its adjacency lists point right into the mapped file, so it must
+// be closed once it isn't used anymore, and none of them must be used after
+// that.
+type MappedGraph struct {
+	CSRGraph
+	weights []float64
+	data    []byte
+	unmap   func([]byte) error
+}
+
+// OpenMappedGraph maps the binary graph in the file at path in memory, as
+// written by WriteBinaryGraph or WriteBinaryWeightGraph.  It returns an
+// error if the file isn't a binary graph of a version it understands, or if
+// the file doesn't match its checksum.
+func OpenMappedGraph(path string) (*MappedGraph, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := decodeBinaryGraph(data)
+	if err != nil {
+		_ = unmap(data)
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	m.data, m.unmap = data, unmap
+	return m, nil
+}
+
+// decodeBinaryGraph checks the binary graph in data, and points the slices of
+// a MappedGraph into it.
+func decodeBinaryGraph(data []byte) (*MappedGraph, error) {
+	if len(data) < binaryHeaderSize+4 {
+		return nil, fmt.Errorf("too short for a binary graph, %d bytes", len(data))
+	}
+	if string(data[:4]) != binaryMagic {
+		return nil, fmt.Errorf("not a binary graph, magic is %q", data[:4])
+	}
+	if version := binary.LittleEndian.Uint16(data[4:]); version != binaryVersion {
+		return nil, fmt.Errorf("unsupported binary graph version %d", version)
+	}
+	flags := binary.LittleEndian.Uint16(data[6:])
+	if flags&^(binaryDirected|binaryWeighted) != 0 {
+		return nil, fmt.Errorf("unknown binary graph flags %#x", flags)
+	}
+	v := binary.LittleEndian.Uint64(data[8:])
+	e := binary.LittleEndian.Uint64(data[16:])
+	a := binary.LittleEndian.Uint64(data[24:])
+
+	sections := uint64(2)
+	if flags&binaryWeighted != 0 {
+		sections = 3
+	}
+	// sizes this large can't fit in memory, and would overflow below
+	if v > math.MaxInt64/32 || a > math.MaxInt64/32 ||
+		uint64(len(data)) != binaryHeaderSize+8*(v+1)+8*a*(sections-1)+4 {
+		return nil, fmt.Errorf("size of %d bytes doesn't match %d vertices and %d adjacencies", len(data), v, a)
+	}
+
+	body := len(data) - 4
+	if crc32.Checksum(data[:body], binaryTable) != binary.LittleEndian.Uint32(data[body:]) {
+		return nil, ErrBadChecksum
+	}
+
+	m := &MappedGraph{}
+	m.directed = flags&binaryDirected != 0
+	m.e = int(e)
+	start := binaryHeaderSize
+	m.offsets = int64s(data[start : start+8*int(v+1)])
+	start += 8 * int(v+1)
+	m.adj = int64s(data[start : start+8*int(a)])
+	start += 8 * int(a)
+	if flags&binaryWeighted != 0 {
+		m.weights = float64s(data[start : start+8*int(a)])
+	}
+
+	if m.offsets[0] != 0 || m.offsets[v] != int(a) {
+		return nil, fmt.Errorf("offsets don't cover the %d adjacencies", a)
+	}
+	for w := 0; w < int(v); w++ {
+		if m.offsets[w] > m.offsets[w+1] {
+			return nil, fmt.Errorf("offsets of vertex %d decrease", w)
+		}
+	}
+	for _, w := range m.adj {
+		if w < 0 || w >= int(v) {
+			return nil, fmt.Errorf("adjacent vertex %d is out of range", w)
+		}
+	}
+	return m, nil
+}
+
+// nativeInt64 tells if int is a little endian int64, so that the sections of
+// a binary graph can be used as is instead of being decoded.
+var nativeInt64 = strconv.IntSize == 64 && binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+// int64s gives the little endian int64 in data as ints, pointing into data
+// when possible.  data must be 8 bytes aligned, which every section of a
+// mapped binary graph is.
+func int64s(data []byte) []int {
+	n := len(data) / 8
+	if n == 0 {
+		return []int{}
+	}
+	if nativeInt64 && uintptr(unsafe.Pointer(&data[0]))%8 == 0 {
+		return unsafe.Slice((*int)(unsafe.Pointer(&data[0])), n)
+	}
+	ints := make([]int, n)
+	for i := range ints {
+		ints[i] = int(int64(binary.LittleEndian.Uint64(data[8*i:])))
+	}
+	return ints
+}
+
+// float64s is int64s, for the little endian float64 in data.
+func float64s(data []byte) []float64 {
+	n := len(data) / 8
+	if n == 0 {
+		return []float64{}
+	}
+	if nativeInt64 && uintptr(unsafe.Pointer(&data[0]))%8 == 0 {
+		return unsafe.Slice((*float64)(unsafe.Pointer(&data[0])), n)
+	}
+	floats := make([]float64, n)
+	for i := range floats {
+		floats[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	return floats
+}
+
+// Weighted tells if this graph has weights.
+func (m *MappedGraph) Weighted() bool {
+	return m.weights != nil
+}
+
+// Weights gives the weights of the edges going to each vertex of Adj(v),
+// or nil if this graph isn't weighted. The slice must not be changed.
+func (m *MappedGraph) Weights(v int) []float64 {
+	if m.weights == nil {
+		return nil
+	}
+	return m.weights[m.offsets[v]:m.offsets[v+1]:m.offsets[v+1]]
+}
+
+// Close unmaps the file of this graph.  Neither the graph nor the slices it
+// returned can be used after that.
+func (m *MappedGraph) Close() error {
+	if m.data == nil {
+		return nil
+	}
+	data := m.data
+	m.data, m.offsets, m.adj, m.weights = nil, nil, nil, nil
+	return m.unmap(data)
+}
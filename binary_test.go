@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// writeBinaryFile writes a binary graph with write in a temporary file, and
+// gives its path.
+func writeBinaryFile(t *testing.T, write func(*bytes.Buffer) error) string {
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		t.Fatalf("Couldn't write binary graph, %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "graph.bin")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Couldn't write file, %v", err)
+	}
+	return path
+}
+
+func openMapped(t *testing.T, path string) *MappedGraph {
+	m, err := OpenMappedGraph(path)
+	if err != nil {
+		t.Fatalf("Couldn't open binary graph, %v", err)
+	}
+	t.Cleanup(func() {
+		if err := m.Close(); err != nil {
+			t.Errorf("Couldn't close binary graph, %v", err)
+		}
+	})
+	return m
+}
+
+func TestBinaryUngraphRoundTrip(t *testing.T) {
+	g := NewGraph(5)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 3)
+	g.AddEdge(2, 0)
+
+	m := openMapped(t, writeBinaryFile(t, func(buf *bytes.Buffer) error {
+		return WriteBinaryGraph(buf, g)
+	}))
+	if m.Directed() || m.Weighted() {
+		t.Errorf("Should be undirected and unweighted")
+	}
+	compareGraphs(t, g, m)
+	if nativeInt64 && &m.adj[0] != (*int)(unsafe.Pointer(&m.data[binaryHeaderSize+8*(g.V()+1)])) {
+		t.Errorf("Adjacency should point right into the mapped file")
+	}
+	if m.GoString() != g.GoString() {
+		t.Errorf("Should stringify the same, want\n%s\ngot\n%s", g.GoString(), m.GoString())
+	}
+}
+
+func TestBinaryDigraphRoundTrip(t *testing.T) {
+	di := digraphWithCycle()
+
+	m := openMapped(t, writeBinaryFile(t, func(buf *bytes.Buffer) error {
+		return WriteBinaryGraph(buf, di)
+	}))
+	if !m.Directed() {
+		t.Errorf("Should be directed")
+	}
+	compareGraphs(t, di, m)
+
+	// a mapped graph can be written back as is
+	again := openMapped(t, writeBinaryFile(t, func(buf *bytes.Buffer) error {
+		return WriteBinaryGraph(buf, m)
+	}))
+	if !again.Directed() {
+		t.Errorf("Should still be directed")
+	}
+	compareGraphs(t, di, again)
+}
+
+func TestBinaryWeightGraphRoundTrip(t *testing.T) {
+	wg := NewWeightGraph(4)
+	wg.AddEdge(NewEdge(0, 1, 0.1))
+	wg.AddEdge(NewEdge(0, 2, 1.0/3.0))
+	wg.AddEdge(NewEdge(3, 2, -4))
+
+	m := openMapped(t, writeBinaryFile(t, func(buf *bytes.Buffer) error {
+		return WriteBinaryWeightGraph(buf, &wg)
+	}))
+	if m.Directed() || !m.Weighted() {
+		t.Errorf("Should be undirected and weighted")
+	}
+	if m.V() != wg.V() || m.E() != wg.E() {
+		t.Fatalf("Want V=%d E=%d, got V=%d E=%d", wg.V(), wg.E(), m.V(), m.E())
+	}
+	for v := 0; v < wg.V(); v++ {
+		adj, weights := m.Adj(v), m.Weights(v)
+		if len(adj) != len(wg.Adj(v)) || len(weights) != len(adj) {
+			t.Fatalf("Vertex %d should have %d edges, got %v %v", v, len(wg.Adj(v)), adj, weights)
+		}
+		for i, e := range wg.Adj(v) {
+			if adj[i] != e.Other(v) || weights[i] != e.Weight() {
+				t.Errorf("Edge %d of %d should be %#v, got %d %f", i, v, &e, adj[i], weights[i])
+			}
+		}
+	}
+}
+
+func TestBinaryEmptyGraph(t *testing.T) {
+	m := openMapped(t, writeBinaryFile(t, func(buf *bytes.Buffer) error {
+		return WriteBinaryGraph(buf, NewGraph(0))
+	}))
+	if m.V() != 0 || m.E() != 0 {
+		t.Errorf("Should be empty, V=%d E=%d", m.V(), m.E())
+	}
+}
+
+func TestBinaryRejectsBadFiles(t *testing.T) {
+	g := NewGraph(3)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	var buf bytes.Buffer
+	if err := WriteBinaryGraph(&buf, g); err != nil {
+		t.Fatalf("Couldn't write binary graph, %v", err)
+	}
+	good := buf.Bytes()
+
+	corrupt := func(i int, b byte) []byte {
+		data := append([]byte(nil), good...)
+		data[i] = b
+		return data
+	}
+
+	for name, data := range map[string][]byte{
+		"empty":     {},
+		"truncated": good[:len(good)-8],
+		"magic":     corrupt(0, 'X'),
+		"version":   corrupt(4, 2),
+		"flags":     corrupt(6, 0x80),
+		"adjacency": corrupt(binaryHeaderSize+8*4, 2),
+		"checksum":  corrupt(len(good)-1, good[len(good)-1]^1),
+	} {
+		path := writeBinaryFile(t, func(buf *bytes.Buffer) error {
+			_, err := buf.Write(data)
+			return err
+		})
+		m, err := OpenMappedGraph(path)
+		if err == nil {
+			m.Close()
+			t.Errorf("%s: should have refused the file", name)
+		}
+		if (name == "adjacency" || name == "checksum") && !errors.Is(err, ErrBadChecksum) {
+			t.Errorf("%s: should fail the checksum, got %v", name, err)
+		}
+	}
+
+	if _, err := OpenMappedGraph(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("Should have failed opening a missing file")
+	}
+}
+
+func Benchmark_OpenMappedGraph_1M(b *testing.B) {
+	g, err := ReadGraph(strings.NewReader(csrBenchGraph()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteBinaryGraph(&buf, g); err != nil {
+		b.Fatal(err)
+	}
+	path := filepath.Join(b.TempDir(), "graph.bin")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, err := OpenMappedGraph(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		m.Close()
+	}
+}
@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"bytes"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCSRMatchesUngraph(t *testing.T) {
+	g := NewGraph(5)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 3)
+	g.AddEdge(2, 0)
+
+	c := BuildCSR(g)
+	if c.Directed() {
+		t.Errorf("CSR of an Ungraph should not be directed")
+	}
+	compareGraphs(t, g, c)
+	if c.GoString() != g.GoString() {
+		t.Errorf("Should stringify the same, want\n%s\ngot\n%s", g.GoString(), c.GoString())
+	}
+}
+
+func TestCSRMatchesDigraph(t *testing.T) {
+	di := digraphWithCycle()
+
+	c := BuildCSR(di)
+	if !c.Directed() {
+		t.Errorf("CSR of a Digraph should be directed")
+	}
+	compareGraphs(t, di, c)
+	if c.GoString() != di.GoString() {
+		t.Errorf("Should stringify the same, want\n%s\ngot\n%s", di.GoString(), c.GoString())
+	}
+}
+
+func TestReadCSRMatchesReadGraph(t *testing.T) {
+	r := rand.New(rand.NewSource(23))
+	for i := 0; i < 20; i++ {
+		v := 1 + r.Intn(50)
+		e := r.Intn(3 * v)
+		input := strconv.Itoa(v) + "\n" + strconv.Itoa(e) + "\n"
+		for ; e > 0; e-- {
+			input += strconv.Itoa(r.Intn(v)) + " " + strconv.Itoa(r.Intn(v)) + "\n"
+		}
+
+		g, err := ReadGraph(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Couldn't read graph, %v", err)
+		}
+		c, err := ReadCSRGraph(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Couldn't read CSR graph, %v", err)
+		}
+		compareGraphs(t, g, c)
+
+		di, err := ReadDigraph(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Couldn't read digraph, %v", err)
+		}
+		c, err = ReadCSRDigraph(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Couldn't read CSR digraph, %v", err)
+		}
+		compareGraphs(t, di, c)
+	}
+}
+
+func TestReadBadCSR(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"3",
+		"3 1 0",
+		"3 1 0 3",
+		"-1 0",
+		"2 -1",
+		"2 1 a 1",
+		// oversized counts must fail, not try to allocate for them
+		"4\n4611686018427387904\n",
+		"4611686018427387904\n1\n0 1\n",
+	} {
+		if _, err := ReadCSRGraph(strings.NewReader(input)); err == nil {
+			t.Errorf("Should have failed reading %q", input)
+		}
+	}
+}
+
+func TestCSRIsImmutable(t *testing.T) {
+	c := BuildCSR(NewGraph(2))
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AddEdge should panic on a CSRGraph")
+		}
+	}()
+	c.AddEdge(0, 1)
+}
+
+func TestCSRZeroValue(t *testing.T) {
+	var c CSRGraph
+	if c.V() != 0 || c.E() != 0 {
+		t.Errorf("Zero CSRGraph should be empty, V=%d E=%d", c.V(), c.E())
+	}
+}
+
+// The memory benchmarks load the same random graph of 1M vertices and 4M
+// edges, as an Ungraph and as a CSRGraph, and report the heap each of them
+// retains once loaded as retained-B/op.
+const csrBenchV = 1000000
+
+var csrBenchInput string
+
+func csrBenchGraph() string {
+	if csrBenchInput == "" {
+		r := rand.New(rand.NewSource(23))
+		var buf bytes.Buffer
+		buf.WriteString(strconv.Itoa(csrBenchV) + "\n" + strconv.Itoa(4*csrBenchV) + "\n")
+		for e := 0; e < 4*csrBenchV; e++ {
+			buf.WriteString(strconv.Itoa(r.Intn(csrBenchV)) + " " + strconv.Itoa(r.Intn(csrBenchV)) + "\n")
+		}
+		csrBenchInput = buf.String()
+	}
+	return csrBenchInput
+}
+
+// benchmarkRetained times load, and measures the heap still in use by the
+// graph it gives once the garbage of loading it is collected.
+func benchmarkRetained(load func() (Graph, error), b *testing.B) {
+	var before, after runtime.MemStats
+	var retained int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		b.StartTimer()
+
+		g, err := load()
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.StopTimer()
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		retained += int64(after.HeapAlloc) - int64(before.HeapAlloc)
+		runtime.KeepAlive(g)
+		b.StartTimer()
+	}
+	b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+}
+
+func Benchmark_Ungraph_1M(b *testing.B) {
+	input := csrBenchGraph()
+	benchmarkRetained(func() (Graph, error) {
+		return ReadGraph(strings.NewReader(input))
+	}, b)
+}
+
+func Benchmark_CSRGraph_1M(b *testing.B) {
+	input := csrBenchGraph()
+	benchmarkRetained(func() (Graph, error) {
+		return ReadCSRGraph(strings.NewReader(input))
+	}, b)
+}
+
+func Benchmark_BuildCSR_1M(b *testing.B) {
+	g, err := ReadGraph(strings.NewReader(csrBenchGraph()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkRetained(func() (Graph, error) {
+		return BuildCSR(g), nil
+	}, b)
+}
@@ -8,19 +8,19 @@ import (
 	"strconv"
 )
 
-// Digraph is a directed graph implementation using an adjacency list
+// Digraph is a directed graph implementation using an adjacency list.
+// Copies of a Digraph share its vertices, edges and edge count.
 type Digraph struct {
-	v   int
 	e   *int
-	adj [][]int
+	adj *[][]int
 }
 
 // NewDigraph returns a digraph with v vertices, all disconnected
 func NewDigraph(v int) Digraph {
+	adj := make([][]int, v)
 	return Digraph{
-		v:   v,
 		e:   new(int),
-		adj: make([][]int, v),
+		adj: &adj,
 	}
 }
 
@@ -62,18 +62,67 @@ func ReadDigraph(input io.Reader) (Digraph, error) {
 
 // AddEdge adds an edge from v to w, but not from w to v. This is O(1).
 func (di Digraph) AddEdge(v, w int) {
-	di.adj[v] = append(di.adj[v], w)
+	(*di.adj)[v] = append((*di.adj)[v], w)
 	(*di.e)++
 }
 
+// HasEdge tells if there is an edge from v to w. This is O(outdegree(v)).
+func (di Digraph) HasEdge(v, w int) bool {
+	return indexOf((*di.adj)[v], w) != -1
+}
+
+// RemoveEdge removes one edge from v to w, telling if there was one. Copies
+// of this digraph share its edges and edge count, and see the removal.
+// Slices previously returned by Adj(v) may change. This is O(outdegree(v)).
+func (di Digraph) RemoveEdge(v, w int) bool {
+	var ok bool
+	if (*di.adj)[v], ok = removeLast((*di.adj)[v], w); !ok {
+		return false
+	}
+	(*di.e)--
+	return true
+}
+
+// RemoveVertex removes every edge from and to v. Vertices are named by
+// their index, so v itself stays in the digraph, isolated, which keeps the
+// names of the other vertices. Copies of this digraph see the removal. The
+// adjacency lists of the vertices with an edge to v are filtered in place,
+// so slices previously returned by Adj for them may change. This is
+// O(E + V).
+func (di Digraph) RemoveVertex(v int) {
+	*di.e -= len((*di.adj)[v])
+	(*di.adj)[v] = nil
+	for u := range *di.adj {
+		kept := (*di.adj)[u][:0]
+		for _, w := range (*di.adj)[u] {
+			if w != v {
+				kept = append(kept, w)
+			}
+		}
+		*di.e -= len((*di.adj)[u]) - len(kept)
+		(*di.adj)[u] = kept
+	}
+}
+
+// AddVertex adds an isolated vertex to the digraph, returning it. Copies of
+// this digraph share its vertices, and see the new one. This is O(1)
+// amortized.
+func (di Digraph) AddVertex() int {
+	*di.adj = append(*di.adj, nil)
+	return len(*di.adj) - 1
+}
+
 // Adj is a slice of vertices adjacent to v. This is O(E)
 func (di Digraph) Adj(v int) []int {
-	return di.adj[v]
+	return (*di.adj)[v]
 }
 
 // V is the number of vertices.
 func (di Digraph) V() int {
-	return di.v
+	if di.adj == nil {
+		return 0
+	}
+	return len(*di.adj)
 }
 
 // E is the number of edges.
@@ -138,21 +187,31 @@ func (d *DAG) Sort() []int {
 
 	var revPostOrder []int
 
-	var visit func(int)
+	var stack []dfsFrame
 
-	visit = func(v int) {
-		marked[v] = true
-		for _, adj := range d.Adj(v) {
-			if !marked[adj] {
-				visit(adj)
-			}
+	for s := 0; s < d.V(); s++ {
+		if marked[s] {
+			continue
 		}
-		revPostOrder = append(revPostOrder, v)
-	}
+		marked[s] = true
+		stack = append(stack, dfsFrame{v: s})
+
+		for len(stack) != 0 {
+			f := &stack[len(stack)-1]
+			v := f.v
+			adj := d.Adj(v)
+			if f.next == len(adj) {
+				stack = stack[:len(stack)-1]
+				revPostOrder = append(revPostOrder, v)
+				continue
+			}
+			w := adj[f.next]
+			f.next++
 
-	for v := 0; v < d.V(); v++ {
-		if !marked[v] {
-			visit(v)
+			if !marked[w] {
+				marked[w] = true
+				stack = append(stack, dfsFrame{v: w})
+			}
 		}
 	}
 
@@ -166,21 +225,34 @@ func DirectedCycle(di Digraph) []int {
 	edgeTo := make([]int, di.V())
 	onStack := make([]bool, di.V())
 	var cycle []int
-	hasCycle := func() bool {
-		return len(cycle) != 0
-	}
 
-	var dfs func(v int)
+	var stack []dfsFrame
 
-	dfs = func(v int) {
-		onStack[v] = true
-		marked[v] = true
-		for _, w := range di.Adj(v) {
-			if hasCycle() {
-				return
-			} else if !marked[w] {
+	for s := 0; s < di.V() && len(cycle) == 0; s++ {
+		if marked[s] {
+			continue
+		}
+		marked[s] = true
+		onStack[s] = true
+		stack = append(stack, dfsFrame{v: s})
+
+		for len(stack) != 0 && len(cycle) == 0 {
+			f := &stack[len(stack)-1]
+			v := f.v
+			adj := di.Adj(v)
+			if f.next == len(adj) {
+				stack = stack[:len(stack)-1]
+				onStack[v] = false
+				continue
+			}
+			w := adj[f.next]
+			f.next++
+
+			if !marked[w] {
 				edgeTo[w] = v
-				dfs(w)
+				marked[w] = true
+				onStack[w] = true
+				stack = append(stack, dfsFrame{v: w})
 			} else if onStack[w] {
 				for x := v; x != w; x = edgeTo[x] {
 					cycle = append(cycle, x)
@@ -189,13 +261,6 @@ func DirectedCycle(di Digraph) []int {
 				cycle = append(cycle, v)
 			}
 		}
-		onStack[v] = false
-	}
-
-	for v := 0; v < di.V(); v++ {
-		if !marked[v] {
-			dfs(v)
-		}
 	}
 
 	return reverse(cycle)
@@ -238,3 +238,100 @@ func TestDigraphFromFile(t *testing.T) {
 		t.Errorf("1 should not be adjacent to %d", v)
 	}
 }
+
+func TestDeepDigraph(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Not running test on deep graphs")
+	}
+	di := deepDigraph(deepGraphSize)
+
+	dag, err := NewDAG(di)
+	if err != nil {
+		t.Fatalf("Path digraph should be a DAG, %v", err)
+	}
+
+	order := dag.Sort()
+	if len(order) != deepGraphSize {
+		t.Fatalf("Expected order len=%d but was %d", deepGraphSize, len(order))
+	}
+	for i, v := range order {
+		if i != v {
+			t.Fatalf("Expected %d at position %d but was %d", i, i, v)
+		}
+	}
+
+	di.AddEdge(deepGraphSize-1, 0)
+	cycle := DirectedCycle(di)
+	if len(cycle) != deepGraphSize+1 {
+		t.Errorf("Expected cycle len=%d but was %d", deepGraphSize+1, len(cycle))
+	}
+}
+
+func TestDigraphRemoveEdge(t *testing.T) {
+	di := NewDigraph(3)
+	di.AddEdge(0, 1)
+	di.AddEdge(0, 1)
+	di.AddEdge(1, 0)
+	di.AddEdge(2, 2)
+
+	if !di.HasEdge(0, 1) || !di.HasEdge(1, 0) || di.HasEdge(1, 2) {
+		t.Fatalf("HasEdge doesn't match edges added in\n%s", di.GoString())
+	}
+
+	if !di.RemoveEdge(1, 0) {
+		t.Fatalf("Should have removed the edge 1->0")
+	}
+	if di.HasEdge(1, 0) || !di.HasEdge(0, 1) {
+		t.Errorf("Should only have removed 1->0, not 0->1")
+	}
+	if !di.RemoveEdge(2, 2) || di.HasEdge(2, 2) {
+		t.Errorf("Should have removed the self-loop on 2")
+	}
+	if di.RemoveEdge(1, 2) {
+		t.Errorf("Should not have removed an edge that doesn't exist")
+	}
+	if di.E() != 2 {
+		t.Errorf("Expected 2 edges but was %d", di.E())
+	}
+}
+
+func TestDigraphRemoveVertex(t *testing.T) {
+	di := NewDigraph(4)
+	di.AddEdge(0, 1)
+	di.AddEdge(1, 2)
+	di.AddEdge(2, 1)
+	di.AddEdge(2, 1)
+	di.AddEdge(1, 1)
+	di.AddEdge(2, 3)
+
+	di.RemoveVertex(1)
+
+	if di.V() != 4 {
+		t.Errorf("Should still have 4 vertices, had %d", di.V())
+	}
+	if di.E() != 1 || !di.HasEdge(2, 3) {
+		t.Errorf("Should only have the edge 2->3 left, E=%d in\n%s", di.E(), di.GoString())
+	}
+}
+
+func TestDigraphCopiesShareEdgeCount(t *testing.T) {
+	di := NewDigraph(2)
+	cp := di
+	cp.AddEdge(0, 1)
+	if di.E() != 1 || !di.HasEdge(0, 1) {
+		t.Errorf("Original should see the edge added to the copy, E=%d", di.E())
+	}
+
+	dag := DAG{&di}
+	v := dag.AddVertex()
+	if v != 2 || di.V() != 3 || dag.V() != 3 || cp.V() != 3 {
+		t.Fatalf("DAG should grow the digraph it points to and its copies, V=%d", di.V())
+	}
+
+	di.AddEdge(1, v)
+	cp = di
+	cp.RemoveVertex(0)
+	if di.E() != 1 || di.HasEdge(0, 1) || !dag.HasEdge(1, v) {
+		t.Errorf("Original should see the edges removed from a copy, E=%d", di.E())
+	}
+}
@@ -0,0 +1,252 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+)
+
+// Highlight is a set of vertices and edges to emphasize when writing a graph
+// in the DOT language, such as the edges of an MST or the vertices of a path.
+type Highlight struct {
+	vertices map[int]bool
+	edges    map[[2]int]bool
+}
+
+// NewHighlight creates an empty set of highlighted vertices and edges.
+func NewHighlight() *Highlight {
+	return &Highlight{
+		vertices: make(map[int]bool),
+		edges:    make(map[[2]int]bool),
+	}
+}
+
+// AddVertex highlights vertex v.
+func (h *Highlight) AddVertex(v int) {
+	h.vertices[v] = true
+}
+
+// AddEdge highlights the edge from v to w, and both its vertices.  In an
+// undirected graph, this also highlights the edge from w to v.
+func (h *Highlight) AddEdge(v, w int) {
+	h.AddVertex(v)
+	h.AddVertex(w)
+	h.edges[[2]int{v, w}] = true
+}
+
+// AddPath highlights every vertex of path, and the edges between them.
+func (h *Highlight) AddPath(path []int) {
+	for i, v := range path {
+		h.AddVertex(v)
+		if i > 0 {
+			h.AddEdge(path[i-1], v)
+		}
+	}
+}
+
+func (h *Highlight) hasVertex(v int) bool {
+	return h != nil && h.vertices[v]
+}
+
+func (h *Highlight) hasEdge(v, w int, directed bool) bool {
+	if h == nil {
+		return false
+	}
+	return h.edges[[2]int{v, w}] || (!directed && h.edges[[2]int{w, v}])
+}
+
+// dotWriter writes vertices and edges in the DOT language, remembering the
+// first error it encounters.
+type dotWriter struct {
+	stickyWriter
+	directed bool
+	hl       *Highlight
+}
+
+const dotHighlight = "color=red, penwidth=2"
+
+func newDotWriter(w io.Writer, directed bool, hl *Highlight) *dotWriter {
+	d := &dotWriter{stickyWriter: newStickyWriter(w), directed: directed, hl: hl}
+	if directed {
+		d.printf("digraph {\n")
+	} else {
+		d.printf("graph {\n")
+	}
+	return d
+}
+
+func (d *dotWriter) vertex(v int) {
+	if d.hl.hasVertex(v) {
+		d.printf("  %d [%s];\n", v, dotHighlight)
+	} else {
+		d.printf("  %d;\n", v)
+	}
+}
+
+func (d *dotWriter) edge(v, w int, attrs string) {
+	op := "--"
+	if d.directed {
+		op = "->"
+	}
+	if d.hl.hasEdge(v, w, d.directed) {
+		if attrs != "" {
+			attrs += ", "
+		}
+		attrs += dotHighlight
+	}
+	if attrs != "" {
+		d.printf("  %d %s %d [%s];\n", v, op, w, attrs)
+	} else {
+		d.printf("  %d %s %d;\n", v, op, w)
+	}
+}
+
+func (d *dotWriter) weightedEdge(v, w int, weight float64) {
+	d.edge(v, w, fmt.Sprintf("label=%q", formatWeight(weight)))
+}
+
+func (d *dotWriter) close() error {
+	d.printf("}\n")
+	return d.flush()
+}
+
+// WriteGraphDOT writes undirected graph g to w in the DOT language used by
+// Graphviz.  Vertices and edges in hl are highlighted, hl can be nil.
+func WriteGraphDOT(w io.Writer, g Ungraph, hl *Highlight) error {
+	d := newDotWriter(w, false, hl)
+	for v := 0; v < g.V(); v++ {
+		d.vertex(v)
+	}
+	eachUndirectedEdge(g.V(), g.Adj, adjVertex, func(v, w, _ int) {
+		d.edge(v, w, "")
+	})
+	return d.close()
+}
+
+// WriteDigraphDOT writes digraph di to w in the DOT language used by
+// Graphviz.  Vertices and edges in hl are highlighted, hl can be nil.
+func WriteDigraphDOT(w io.Writer, di Digraph, hl *Highlight) error {
+	d := newDotWriter(w, true, hl)
+	for v := 0; v < di.V(); v++ {
+		d.vertex(v)
+	}
+	for v := 0; v < di.V(); v++ {
+		for _, adj := range di.Adj(v) {
+			d.edge(v, adj, "")
+		}
+	}
+	return d.close()
+}
+
+// WriteWeightGraphDOT writes weighted graph wg to w in the DOT language used
+// by Graphviz, with the weights as edge labels.  Vertices and edges in hl are
+// highlighted, hl can be nil.
+func WriteWeightGraphDOT(w io.Writer, wg *WeightGraph, hl *Highlight) error {
+	d := newDotWriter(w, false, hl)
+	for v := 0; v < wg.V(); v++ {
+		d.vertex(v)
+	}
+	eachUndirectedEdge(wg.V(), wg.Adj, edgeOther, func(v, w int, e Edge) {
+		d.weightedEdge(v, w, e.Weight())
+	})
+	return d.close()
+}
+
+// WriteWeightDigraphDOT writes weighted digraph wd to w in the DOT language
+// used by Graphviz, with the weights as edge labels.  Vertices and edges in
+// hl are highlighted, hl can be nil.
+func WriteWeightDigraphDOT(w io.Writer, wd *EdgeWeightedDigraph, hl *Highlight) error {
+	d := newDotWriter(w, true, hl)
+	for v := 0; v < wd.V(); v++ {
+		d.vertex(v)
+	}
+	for _, e := range wd.Edges() {
+		d.weightedEdge(e.From(), e.To(), e.Weight())
+	}
+	return d.close()
+}
+
+// ReadGraphDOT constructs an undirected graph from a `graph` written in the
+// DOT language, such as the output of WriteGraphDOT.  Vertices must be
+// named by non-negative integers below MaxReadVertices, the vertex count is
+// one more than the largest of them.  Attributes are ignored.
+func ReadGraphDOT(input io.Reader) (Ungraph, error) {
+	dot, err := parseDOT(input)
+	if err != nil {
+		return Ungraph{}, err
+	}
+	if dot.directed {
+		return Ungraph{}, fmt.Errorf("expected a graph but got a digraph")
+	}
+	g := NewGraph(dot.v)
+	for _, e := range dot.edges {
+		g.AddEdge(e.from, e.to)
+	}
+	return g, nil
+}
+
+// ReadDigraphDOT constructs a digraph from a `digraph` written in the DOT
+// language, such as the output of WriteDigraphDOT.  Vertices must be named
+// by non-negative integers below MaxReadVertices, the vertex count is one
+// more than the largest of them.  Attributes are ignored.
+func ReadDigraphDOT(input io.Reader) (Digraph, error) {
+	dot, err := parseDOT(input)
+	if err != nil {
+		return Digraph{}, err
+	}
+	if !dot.directed {
+		return Digraph{}, fmt.Errorf("expected a digraph but got a graph")
+	}
+	di := NewDigraph(dot.v)
+	for _, e := range dot.edges {
+		di.AddEdge(e.from, e.to)
+	}
+	return di, nil
+}
+
+// ReadWeightGraphDOT constructs a weighted graph from a `graph` written in
+// the DOT language, such as the output of WriteWeightGraphDOT.  Vertices
+// must be named by non-negative integers below MaxReadVertices, the vertex
+// count is one more than the largest of them.  Every edge needs a `weight`
+// or `label` attribute holding its weight.
+func ReadWeightGraphDOT(input io.Reader) (WeightGraph, error) {
+	dot, err := parseDOT(input)
+	if err != nil {
+		return WeightGraph{}, err
+	}
+	if dot.directed {
+		return WeightGraph{}, fmt.Errorf("expected a graph but got a digraph")
+	}
+	wg := NewWeightGraph(dot.v)
+	for _, e := range dot.edges {
+		weight, err := e.weight()
+		if err != nil {
+			return wg, err
+		}
+		wg.AddEdge(NewEdge(e.from, e.to, weight))
+	}
+	return wg, nil
+}
+
+// ReadWeightDigraphDOT constructs a weighted digraph from a `digraph` written
+// in the DOT language, such as the output of WriteWeightDigraphDOT.
+// Vertices must be named by non-negative integers below MaxReadVertices, the
+// vertex count is one more than the largest of them.  Every edge needs a
+// `weight` or `label` attribute holding its weight.
+func ReadWeightDigraphDOT(input io.Reader) (EdgeWeightedDigraph, error) {
+	dot, err := parseDOT(input)
+	if err != nil {
+		return EdgeWeightedDigraph{}, err
+	}
+	if !dot.directed {
+		return EdgeWeightedDigraph{}, fmt.Errorf("expected a digraph but got a graph")
+	}
+	wd := NewEdgeWeightedDigraph(dot.v)
+	for _, e := range dot.edges {
+		weight, err := e.weight()
+		if err != nil {
+			return wd, err
+		}
+		wd.AddEdge(NewDirectedEdge(e.from, e.to, weight))
+	}
+	return wd, nil
+}
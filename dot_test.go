@@ -0,0 +1,231 @@
+package graph
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func sortedAdj(g Graph, v int) []int {
+	adj := append([]int{}, g.Adj(v)...)
+	sort.Ints(adj)
+	return adj
+}
+
+func compareGraphs(t *testing.T, want, got Graph) {
+	if want.V() != got.V() {
+		t.Fatalf("Vertex count, want %d got %d", want.V(), got.V())
+	}
+	if want.E() != got.E() {
+		t.Errorf("Edge count, want %d got %d", want.E(), got.E())
+	}
+	for v := 0; v < want.V(); v++ {
+		w, g := sortedAdj(want, v), sortedAdj(got, v)
+		if len(w) != len(g) {
+			t.Errorf("Adjacency of %d, want %v got %v", v, w, g)
+			continue
+		}
+		for i := range w {
+			if w[i] != g[i] {
+				t.Errorf("Adjacency of %d, want %v got %v", v, w, g)
+				break
+			}
+		}
+	}
+}
+
+func TestGraphDOTRoundTrip(t *testing.T) {
+	g := NewGraph(5)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 3)
+	g.AddEdge(2, 0)
+	// 4 is isolated
+
+	var buf bytes.Buffer
+	if err := WriteGraphDOT(&buf, g, nil); err != nil {
+		t.Fatalf("Couldn't write DOT, %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "graph {") {
+		t.Errorf("Should be written as a graph, got\n%s", buf.String())
+	}
+
+	got, err := ReadGraphDOT(&buf)
+	if err != nil {
+		t.Fatalf("Couldn't read DOT, %v", err)
+	}
+	compareGraphs(t, g, got)
+}
+
+func TestDigraphDOTRoundTrip(t *testing.T) {
+	di := digraphWithCycle()
+
+	var buf bytes.Buffer
+	if err := WriteDigraphDOT(&buf, di, nil); err != nil {
+		t.Fatalf("Couldn't write DOT, %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "digraph {") {
+		t.Errorf("Should be written as a digraph, got\n%s", buf.String())
+	}
+
+	got, err := ReadDigraphDOT(&buf)
+	if err != nil {
+		t.Fatalf("Couldn't read DOT, %v", err)
+	}
+	compareGraphs(t, di, got)
+}
+
+func TestWeightGraphDOTRoundTrip(t *testing.T) {
+	wg := NewWeightGraph(4)
+	wg.AddEdge(NewEdge(0, 1, 0.1))
+	wg.AddEdge(NewEdge(0, 2, 1.0/3.0))
+	wg.AddEdge(NewEdge(3, 2, -4))
+	wg.AddEdge(NewEdge(3, 3, 1e-9))
+
+	var buf bytes.Buffer
+	if err := WriteWeightGraphDOT(&buf, &wg, nil); err != nil {
+		t.Fatalf("Couldn't write DOT, %v", err)
+	}
+
+	got, err := ReadWeightGraphDOT(&buf)
+	if err != nil {
+		t.Fatalf("Couldn't read DOT, %v", err)
+	}
+	if got.V() != wg.V() || got.E() != wg.E() {
+		t.Fatalf("Want V=%d E=%d, got V=%d E=%d", wg.V(), wg.E(), got.V(), got.E())
+	}
+
+	want := make(map[Edge]bool)
+	for v := 0; v < wg.V(); v++ {
+		for _, e := range wg.Adj(v) {
+			want[e] = true
+		}
+	}
+	for v := 0; v < got.V(); v++ {
+		for _, e := range got.Adj(v) {
+			if !want[e] && !want[NewEdge(e.to, e.from, e.weight)] {
+				t.Errorf("Unexpected edge %#v", &e)
+			}
+		}
+	}
+}
+
+func TestWeightDigraphDOTRoundTrip(t *testing.T) {
+	wd, err := ReadWeightDigraph(strings.NewReader(tinyEWD))
+	if err != nil {
+		t.Fatalf("Couldn't read digraph, %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteWeightDigraphDOT(&buf, &wd, nil); err != nil {
+		t.Fatalf("Couldn't write DOT, %v", err)
+	}
+
+	got, err := ReadWeightDigraphDOT(&buf)
+	if err != nil {
+		t.Fatalf("Couldn't read DOT, %v", err)
+	}
+
+	wantEdges, gotEdges := wd.Edges(), got.Edges()
+	if len(wantEdges) != len(gotEdges) {
+		t.Fatalf("Want %d edges, got %d", len(wantEdges), len(gotEdges))
+	}
+	for i := range wantEdges {
+		if wantEdges[i] != gotEdges[i] {
+			t.Errorf("Want edge %#v, got %#v", &wantEdges[i], &gotEdges[i])
+		}
+	}
+}
+
+func TestDOTHighlight(t *testing.T) {
+	g := NewGraph(4)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+
+	hl := NewHighlight()
+	hl.AddPath([]int{2, 1})
+
+	var buf bytes.Buffer
+	if err := WriteGraphDOT(&buf, g, hl); err != nil {
+		t.Fatalf("Couldn't write DOT, %v", err)
+	}
+
+	for _, want := range []string{
+		"  1 [" + dotHighlight + "];\n",
+		"  2 [" + dotHighlight + "];\n",
+		"  0;\n",
+		"  1 -- 2 [" + dotHighlight + "];\n",
+		"  0 -- 1;\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected to find %q in\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestReadHandWrittenDOT(t *testing.T) {
+	input := `
+	/* a hand written digraph */
+	strict digraph "G" {
+		rankdir = LR
+		node [shape=circle];
+		// chains make many edges
+		0 -> 1 -> 2 [label="0.5", color=blue]
+		2 -> 0 [weight=1.5]; # comment
+		"5"
+	}`
+
+	wd, err := ReadWeightDigraphDOT(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Couldn't read DOT, %v", err)
+	}
+	if wd.V() != 6 {
+		t.Errorf("Vertex count, want %d got %d", 6, wd.V())
+	}
+	if wd.E() != 3 {
+		t.Errorf("Edge count, want %d got %d", 3, wd.E())
+	}
+	for _, e := range wd.Edges() {
+		want := 0.5
+		if e.From() == 2 {
+			want = 1.5
+		}
+		if e.Weight() != want {
+			t.Errorf("Edge %#v should weigh %f", &e, want)
+		}
+	}
+}
+
+func TestReadBadDOT(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"graph { 0 -- 1 ",
+		"digraph { 0 -- 1 }",
+		"graph { a -- b }",
+		"graph { subgraph { 0 -- 1 } }",
+		"graph { \"0 -- 1 }",
+		"graph { 0 -- 1 } graph",
+		"graph { 99999999999 }",
+	} {
+		if _, err := ReadGraphDOT(strings.NewReader(input)); err == nil {
+			t.Errorf("Should have failed reading %q", input)
+		}
+	}
+
+	if _, err := ReadDigraphDOT(strings.NewReader("graph { 0 -- 1 }")); err == nil {
+		t.Errorf("Should have refused a graph as a digraph")
+	}
+	if _, err := ReadWeightGraphDOT(strings.NewReader("graph { 0 -- 1 }")); err == nil {
+		t.Errorf("Should have refused an edge without weight")
+	}
+	if _, err := ReadWeightGraphDOT(strings.NewReader("graph { 0 -- 1 [label=oops] }")); err == nil {
+		t.Errorf("Should have refused an edge with a bad weight")
+	}
+	_, err := ReadDigraphDOT(strings.NewReader("digraph {\n0 -> 1\n1 -> 99999999999\n}"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3: ") {
+		t.Errorf("Should have refused a vertex above the limit on line 3, got %v", err)
+	}
+}
@@ -0,0 +1,405 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// dotGraph is what parseDOT understood of a graph in the DOT language.
+type dotGraph struct {
+	directed bool
+	v        int
+	edges    []dotEdge
+}
+
+type dotEdge struct {
+	from, to int
+	attrs    map[string]string
+}
+
+func (e dotEdge) weight() (float64, error) {
+	text, ok := e.attrs["weight"]
+	if !ok {
+		text, ok = e.attrs["label"]
+	}
+	if !ok {
+		return 0, fmt.Errorf("edge %d-%d has no weight", e.from, e.to)
+	}
+	weight, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("edge %d-%d has bad weight, %v", e.from, e.to, err)
+	}
+	return weight, nil
+}
+
+type dotTokenKind int
+
+const (
+	dotEOF dotTokenKind = iota
+	dotID
+	dotQuoted
+	dotPunct
+	dotEdgeOp
+)
+
+type dotToken struct {
+	kind dotTokenKind
+	text string
+	line int
+}
+
+// isKeyword tells if this token is the unquoted DOT keyword kw, which are
+// case insensitive.
+func (t dotToken) isKeyword(kw string) bool {
+	return t.kind == dotID && strings.EqualFold(t.text, kw)
+}
+
+func (t dotToken) is(punct string) bool {
+	return (t.kind == dotPunct || t.kind == dotEdgeOp) && t.text == punct
+}
+
+func (t dotToken) isID() bool {
+	return t.kind == dotID || t.kind == dotQuoted
+}
+
+// dotScanner splits DOT input into tokens, skipping whitespace and comments.
+type dotScanner struct {
+	rd   *bufio.Reader
+	line int
+}
+
+func (s *dotScanner) read() (rune, bool) {
+	r, _, err := s.rd.ReadRune()
+	if err != nil {
+		return 0, false
+	}
+	if r == '\n' {
+		s.line++
+	}
+	return r, true
+}
+
+func (s *dotScanner) unread(r rune) {
+	if r == '\n' {
+		s.line--
+	}
+	_ = s.rd.UnreadRune()
+}
+
+func (s *dotScanner) peek() rune {
+	r, ok := s.read()
+	if !ok {
+		return 0
+	}
+	s.unread(r)
+	return r
+}
+
+func isDotIDRune(r rune) bool {
+	return r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func (s *dotScanner) Next() (dotToken, error) {
+	for {
+		r, ok := s.read()
+		if !ok {
+			return dotToken{kind: dotEOF, line: s.line}, nil
+		}
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case r == '#':
+			s.skipLine()
+			continue
+		case r == '/' && s.peek() == '/':
+			s.skipLine()
+			continue
+		case r == '/' && s.peek() == '*':
+			s.read()
+			if err := s.skipBlockComment(); err != nil {
+				return dotToken{}, err
+			}
+			continue
+		case r == '"':
+			return s.quoted()
+		case r == '-' && (s.peek() == '-' || s.peek() == '>'):
+			next, _ := s.read()
+			return dotToken{kind: dotEdgeOp, text: string([]rune{r, next}), line: s.line}, nil
+		case r == '-' || isDotIDRune(r):
+			return s.id(r), nil
+		case strings.ContainsRune("{}[]=;,", r):
+			return dotToken{kind: dotPunct, text: string(r), line: s.line}, nil
+		default:
+			return dotToken{}, fmt.Errorf("line %d: unsupported character %q", s.line, r)
+		}
+	}
+}
+
+func (s *dotScanner) skipLine() {
+	for {
+		r, ok := s.read()
+		if !ok || r == '\n' {
+			return
+		}
+	}
+}
+
+func (s *dotScanner) skipBlockComment() error {
+	start := s.line
+	for {
+		r, ok := s.read()
+		if !ok {
+			return fmt.Errorf("line %d: unterminated comment", start)
+		}
+		if r == '*' && s.peek() == '/' {
+			s.read()
+			return nil
+		}
+	}
+}
+
+func (s *dotScanner) quoted() (dotToken, error) {
+	start := s.line
+	var text []rune
+	for {
+		r, ok := s.read()
+		if !ok {
+			return dotToken{}, fmt.Errorf("line %d: unterminated string", start)
+		}
+		switch r {
+		case '"':
+			return dotToken{kind: dotQuoted, text: string(text), line: start}, nil
+		case '\\':
+			next, ok := s.read()
+			if !ok {
+				return dotToken{}, fmt.Errorf("line %d: unterminated string", start)
+			}
+			switch next {
+			case '"':
+				text = append(text, next)
+			case '\n':
+				// line continuation
+			default:
+				text = append(text, r, next)
+			}
+		default:
+			text = append(text, r)
+		}
+	}
+}
+
+func (s *dotScanner) id(first rune) dotToken {
+	text := []rune{first}
+	for {
+		r, ok := s.read()
+		if !ok {
+			break
+		}
+		if !isDotIDRune(r) {
+			s.unread(r)
+			break
+		}
+		text = append(text, r)
+	}
+	return dotToken{kind: dotID, text: string(text), line: s.line}
+}
+
+// dotParser understands the subset of the DOT language made of node, edge
+// and attribute statements.  Subgraphs and ports are not supported.
+type dotParser struct {
+	scan *dotScanner
+	tok  dotToken
+	g    *dotGraph
+}
+
+func parseDOT(input io.Reader) (*dotGraph, error) {
+	p := &dotParser{
+		scan: &dotScanner{rd: bufio.NewReader(input), line: 1},
+		g:    &dotGraph{},
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.parseGraph(); err != nil {
+		return nil, err
+	}
+	return p.g, nil
+}
+
+func (p *dotParser) advance() error {
+	tok, err := p.scan.Next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *dotParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.tok.line, fmt.Sprintf(format, args...))
+}
+
+func (p *dotParser) expect(punct string) error {
+	if !p.tok.is(punct) {
+		return p.errorf("expected %q but got %q", punct, p.tok.text)
+	}
+	return p.advance()
+}
+
+func (p *dotParser) parseGraph() error {
+	if p.tok.isKeyword("strict") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.tok.isKeyword("graph"):
+	case p.tok.isKeyword("digraph"):
+		p.g.directed = true
+	default:
+		return p.errorf("expected graph or digraph but got %q", p.tok.text)
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.tok.isID() {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.tok.is("}") {
+		if p.tok.kind == dotEOF {
+			return p.errorf("expected \"}\" before end of input")
+		}
+		if err := p.parseStmt(); err != nil {
+			return err
+		}
+		if p.tok.is(";") {
+			if err := p.advance(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.tok.kind != dotEOF {
+		return p.errorf("unexpected %q after graph", p.tok.text)
+	}
+	return nil
+}
+
+func (p *dotParser) parseStmt() error {
+	switch {
+	case p.tok.isKeyword("graph"), p.tok.isKeyword("node"), p.tok.isKeyword("edge"):
+		if err := p.advance(); err != nil {
+			return err
+		}
+		_, err := p.parseAttrs()
+		return err
+	case p.tok.isKeyword("subgraph"), p.tok.is("{"):
+		return p.errorf("subgraphs are not supported")
+	case !p.tok.isID():
+		return p.errorf("unexpected %q", p.tok.text)
+	}
+
+	first := p.tok
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.tok.is("=") {
+		// graph attribute, such as `rankdir=LR`
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if !p.tok.isID() {
+			return p.errorf("expected a value for %q", first.text)
+		}
+		return p.advance()
+	}
+
+	vertices := []dotToken{first}
+	for p.tok.kind == dotEdgeOp {
+		if p.g.directed != p.tok.is("->") {
+			return p.errorf("edge operator %q doesn't match graph type", p.tok.text)
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if !p.tok.isID() {
+			return p.errorf("expected a vertex after edge operator but got %q", p.tok.text)
+		}
+		vertices = append(vertices, p.tok)
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	attrs, err := p.parseAttrs()
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, len(vertices))
+	for i, tok := range vertices {
+		v, err := strconv.Atoi(tok.text)
+		if err != nil || v < 0 {
+			return fmt.Errorf("line %d: vertex %q is not a non-negative integer", tok.line, tok.text)
+		}
+		if v >= MaxReadVertices {
+			return fmt.Errorf("line %d: vertex %d is above the limit of %d vertices", tok.line, v, MaxReadVertices)
+		}
+		if v >= p.g.v {
+			p.g.v = v + 1
+		}
+		ids[i] = v
+	}
+	for i := 1; i < len(ids); i++ {
+		p.g.edges = append(p.g.edges, dotEdge{from: ids[i-1], to: ids[i], attrs: attrs})
+	}
+	return nil
+}
+
+func (p *dotParser) parseAttrs() (map[string]string, error) {
+	attrs := make(map[string]string)
+	for p.tok.is("[") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for !p.tok.is("]") {
+			if !p.tok.isID() {
+				return nil, p.errorf("expected an attribute name but got %q", p.tok.text)
+			}
+			key := p.tok.text
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			if !p.tok.isID() {
+				return nil, p.errorf("expected a value for attribute %q", key)
+			}
+			attrs[key] = p.tok.text
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.is(",") || p.tok.is(";") {
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return attrs, nil
+}
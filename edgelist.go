@@ -0,0 +1,277 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// edgeListField is a field of an edge list line, and the column where it
+// starts.
+type edgeListField struct {
+	text string
+	col  int
+}
+
+// edgeListScanner reads an edge list one line at a time, skipping blank
+// lines and `#` comments, and remembers the largest vertex seen.
+type edgeListScanner struct {
+	scan   *bufio.Scanner
+	line   int
+	fields []edgeListField
+	max    int
+}
+
+func newEdgeListScanner(input io.Reader) *edgeListScanner {
+	return &edgeListScanner{scan: bufio.NewScanner(input), max: -1}
+}
+
+// Next moves to the next line holding an edge, telling if there is one.
+func (s *edgeListScanner) Next() bool {
+	for s.scan.Scan() {
+		s.line++
+		text := s.scan.Text()
+		if i := strings.IndexByte(text, '#'); i != -1 {
+			text = text[:i]
+		}
+
+		s.fields = s.fields[:0]
+		start := -1
+		for i, r := range text + " " {
+			switch {
+			case unicode.IsSpace(r) && start != -1:
+				s.fields = append(s.fields, edgeListField{text: text[start:i], col: start + 1})
+				start = -1
+			case !unicode.IsSpace(r) && start == -1:
+				start = i
+			}
+		}
+		if len(s.fields) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Err is the error that stopped the scanner, if any.
+func (s *edgeListScanner) Err() error {
+	if err := s.scan.Err(); err != nil {
+		return fmt.Errorf("line %d: %v", s.line+1, err)
+	}
+	return nil
+}
+
+func (s *edgeListScanner) errorf(col int, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d, col %d: %s", s.line, col, fmt.Sprintf(format, args...))
+}
+
+// Edge parses the edge on the current line, expecting a weight if weighted.
+func (s *edgeListScanner) Edge(weighted bool) (from, to int, weight float64, err error) {
+	want := 2
+	if weighted {
+		want = 3
+	}
+	if len(s.fields) < want {
+		end := s.fields[len(s.fields)-1]
+		col := end.col + len(end.text)
+		if want == 3 && len(s.fields) == 2 {
+			return 0, 0, 0, s.errorf(col, "expected a weight after the vertices")
+		}
+		return 0, 0, 0, s.errorf(col, "expected %d fields, got %d", want, len(s.fields))
+	}
+	if len(s.fields) > want {
+		extra := s.fields[want]
+		return 0, 0, 0, s.errorf(extra.col, "unexpected %q after the edge", extra.text)
+	}
+
+	if from, err = s.vertex(s.fields[0]); err != nil {
+		return
+	}
+	if to, err = s.vertex(s.fields[1]); err != nil {
+		return
+	}
+	if weighted {
+		f := s.fields[2]
+		if weight, err = strconv.ParseFloat(f.text, 64); err != nil {
+			return 0, 0, 0, s.errorf(f.col, "weight %q is not a number", f.text)
+		}
+	}
+	return
+}
+
+func (s *edgeListScanner) vertex(f edgeListField) (int, error) {
+	v, err := strconv.Atoi(f.text)
+	if err != nil || v < 0 {
+		return 0, s.errorf(f.col, "vertex %q is not a non-negative integer", f.text)
+	}
+	if v >= MaxReadVertices {
+		return 0, s.errorf(f.col, "vertex %d is above the limit of %d vertices", v, MaxReadVertices)
+	}
+	if v > s.max {
+		s.max = v
+	}
+	return v, nil
+}
+
+// ReadEdgeList constructs an undirected graph from the io.Reader expecting
+// to find lines formed such as:
+//
+//	# a comment
+//	a b
+//	c d  # another comment
+//	...
+//
+// where each line is an edge between `a` and `b`, and so on.  There is no
+// vertex or edge count, the graph grows to hold the largest vertex seen,
+// which must be below MaxReadVertices.  Errors tell the line and column
+// where they happen.
+func ReadEdgeList(input io.Reader) (Ungraph, error) {
+	g := NewGraph(0)
+	scan := newEdgeListScanner(input)
+	for scan.Next() {
+		from, to, _, err := scan.Edge(false)
+		if err != nil {
+			return g, err
+		}
+		*g.adj = growAdj(*g.adj, scan.max+1)
+		g.AddEdge(from, to)
+	}
+	return g, scan.Err()
+}
+
+// ReadDigraphEdgeList constructs a digraph from the io.Reader expecting to
+// find lines formed such as:
+//
+//	# a comment
+//	a b
+//	c d  # another comment
+//	...
+//
+// where each line is an edge from `a` to `b`, and so on.  There is no
+// vertex or edge count, the digraph grows to hold the largest vertex seen,
+// which must be below MaxReadVertices.  Errors tell the line and column
+// where they happen.
+func ReadDigraphEdgeList(input io.Reader) (Digraph, error) {
+	di := NewDigraph(0)
+	scan := newEdgeListScanner(input)
+	for scan.Next() {
+		from, to, _, err := scan.Edge(false)
+		if err != nil {
+			return di, err
+		}
+		*di.adj = growAdj(*di.adj, scan.max+1)
+		di.AddEdge(from, to)
+	}
+	return di, scan.Err()
+}
+
+// ReadWeightEdgeList constructs a weighted graph from the io.Reader
+// expecting to find lines formed such as:
+//
+//	# a comment
+//	a b w0
+//	c d w1  # another comment
+//	...
+//
+// where each line is an edge between `a` and `b` of weight `w0`, and so on.
+// There is no vertex or edge count, the graph grows to hold the largest
+// vertex seen, which must be below MaxReadVertices.  Errors tell the line
+// and column where they happen.
+func ReadWeightEdgeList(input io.Reader) (WeightGraph, error) {
+	wg := NewWeightGraph(0)
+	scan := newEdgeListScanner(input)
+	for scan.Next() {
+		from, to, weight, err := scan.Edge(true)
+		if err != nil {
+			return wg, err
+		}
+		wg.adj = growAdj(wg.adj, scan.max+1)
+		wg.AddEdge(NewEdge(from, to, weight))
+	}
+	return wg, scan.Err()
+}
+
+// ReadWeightDigraphEdgeList constructs a weighted digraph from the io.Reader
+// expecting to find lines formed such as:
+//
+//	# a comment
+//	a b w0
+//	c d w1  # another comment
+//	...
+//
+// where each line is an edge from `a` to `b` of weight `w0`, and so on.
+// There is no vertex or edge count, the digraph grows to hold the largest
+// vertex seen, which must be below MaxReadVertices.  Errors tell the line
+// and column where they happen.
+func ReadWeightDigraphEdgeList(input io.Reader) (EdgeWeightedDigraph, error) {
+	wd := NewEdgeWeightedDigraph(0)
+	scan := newEdgeListScanner(input)
+	for scan.Next() {
+		from, to, weight, err := scan.Edge(true)
+		if err != nil {
+			return wd, err
+		}
+		wd.adj = growAdj(wd.adj, scan.max+1)
+		wd.AddEdge(NewDirectedEdge(from, to, weight))
+	}
+	return wd, scan.Err()
+}
+
+// writeHeader starts writing a graph in the format with vertex and edge
+// counts.
+func writeHeader(w io.Writer, v, e int) stickyWriter {
+	s := newStickyWriter(w)
+	s.printf("%d\n%d\n", v, e)
+	return s
+}
+
+// formatWeight writes weight with as many digits as needed to read it back
+// exactly.
+func formatWeight(weight float64) string {
+	return strconv.FormatFloat(weight, 'g', -1, 64)
+}
+
+// WriteGraph writes undirected graph g to w in the format read by ReadGraph.
+func WriteGraph(w io.Writer, g Ungraph) error {
+	s := writeHeader(w, g.V(), g.E())
+	eachUndirectedEdge(g.V(), g.Adj, adjVertex, func(v, w, _ int) {
+		s.printf("%d %d\n", v, w)
+	})
+	return s.flush()
+}
+
+// WriteDigraph writes digraph di to w in the format read by ReadDigraph.
+func WriteDigraph(w io.Writer, di Digraph) error {
+	s := writeHeader(w, di.V(), di.E())
+	for v := 0; v < di.V(); v++ {
+		for _, adj := range di.Adj(v) {
+			s.printf("%d %d\n", v, adj)
+		}
+	}
+	return s.flush()
+}
+
+// WriteWeightGraph writes weighted graph wg to w in the format read by
+// ReadWeightGraph.  Weights are written with as many digits as needed to
+// read them back exactly.
+func WriteWeightGraph(w io.Writer, wg *WeightGraph) error {
+	s := writeHeader(w, wg.V(), wg.E())
+	eachUndirectedEdge(wg.V(), wg.Adj, edgeOther, func(v, w int, e Edge) {
+		s.printf("%d %d %s\n", v, w, formatWeight(e.Weight()))
+	})
+	return s.flush()
+}
+
+// WriteWeightDigraph writes weighted digraph wd to w in the format read by
+// ReadWeightDigraph.  Weights are written with as many digits as needed to
+// read them back exactly.
+func WriteWeightDigraph(w io.Writer, wd *EdgeWeightedDigraph) error {
+	s := writeHeader(w, wd.V(), wd.E())
+	for _, e := range wd.Edges() {
+		s.printf("%d %d %s\n", e.From(), e.To(), formatWeight(e.Weight()))
+	}
+	return s.flush()
+}
@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+const tinyEdgeList = `# tinyG without its header
+0 5
+4 3
+
+0 1   # comments can follow an edge
+9 12
+6 4
+5 4
+0 2
+11 12
+9 10
+0 6
+7 8
+9 11
+5 3
+`
+
+func TestReadEdgeList(t *testing.T) {
+	got, err := ReadEdgeList(strings.NewReader(tinyEdgeList))
+	if err != nil {
+		t.Fatalf("Couldn't read edge list, %v", err)
+	}
+	tinyG := "13\n13\n0 5\n4 3\n0 1\n9 12\n6 4\n5 4\n0 2\n11 12\n9 10\n0 6\n7 8\n9 11\n5 3\n"
+	want, err := ReadGraph(strings.NewReader(tinyG))
+	if err != nil {
+		t.Fatalf("Couldn't read graph, %v", err)
+	}
+	compareGraphs(t, want, got)
+}
+
+func TestReadDigraphEdgeList(t *testing.T) {
+	got, err := ReadDigraphEdgeList(strings.NewReader("0 1\n1 2\n\n# 3 is the largest vertex\n3 3\n"))
+	if err != nil {
+		t.Fatalf("Couldn't read edge list, %v", err)
+	}
+	want := NewDigraph(4)
+	want.AddEdge(0, 1)
+	want.AddEdge(1, 2)
+	want.AddEdge(3, 3)
+	compareGraphs(t, want, got)
+}
+
+func TestReadEdgeListErrors(t *testing.T) {
+	for _, tt := range []struct {
+		input    string
+		weighted bool
+		want     string
+	}{
+		{"0 1\n1\n", false, "line 2, col 2: "},
+		{"0 1\n\n# comment\n1 2 3\n", false, "line 4, col 5: "},
+		{"0 x\n", false, "line 1, col 3: "},
+		{"  -1 2\n", false, "line 1, col 3: "},
+		{"0 1 0.5\n1 2\n", true, "line 2, col 4: "},
+		{"0 1 heavy\n", true, "line 1, col 5: "},
+		{"0 1\n0 99999999999\n", false, "line 2, col 3: "},
+	} {
+		var err error
+		if tt.weighted {
+			_, err = ReadWeightEdgeList(strings.NewReader(tt.input))
+		} else {
+			_, err = ReadEdgeList(strings.NewReader(tt.input))
+		}
+		if err == nil {
+			t.Errorf("Should have failed reading %q", tt.input)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("Reading %q, want error starting with %q, got %q", tt.input, tt.want, err)
+		}
+	}
+}
+
+func TestReadGraphShortInput(t *testing.T) {
+	_, err := ReadGraph(strings.NewReader("3\n2\n0 1\n"))
+	if err == nil || !strings.Contains(err.Error(), io.ErrUnexpectedEOF.Error()) {
+		t.Errorf("Should report the missing edge as an unexpected EOF, got %v", err)
+	}
+}
+
+func TestWriteGraphRoundTrip(t *testing.T) {
+	g := NewGraph(5)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 3)
+	g.AddEdge(2, 0)
+	// 4 is isolated
+
+	var buf bytes.Buffer
+	if err := WriteGraph(&buf, g); err != nil {
+		t.Fatalf("Couldn't write graph, %v", err)
+	}
+	got, err := ReadGraph(&buf)
+	if err != nil {
+		t.Fatalf("Couldn't read graph, %v", err)
+	}
+	compareGraphs(t, g, got)
+}
+
+func TestWriteDigraphRoundTrip(t *testing.T) {
+	di := digraphWithCycle()
+
+	var buf bytes.Buffer
+	if err := WriteDigraph(&buf, di); err != nil {
+		t.Fatalf("Couldn't write digraph, %v", err)
+	}
+	got, err := ReadDigraph(&buf)
+	if err != nil {
+		t.Fatalf("Couldn't read digraph, %v", err)
+	}
+	compareGraphs(t, di, got)
+}
+
+func TestWriteWeightGraphRoundTrip(t *testing.T) {
+	wg, err := ReadWeightEdgeList(strings.NewReader("0 1 0.1\n0 2 0.3333333333333333\n3 2 -4\n3 3 1e-9\n"))
+	if err != nil {
+		t.Fatalf("Couldn't read edge list, %v", err)
+	}
+	if wg.V() != 4 || wg.E() != 4 {
+		t.Fatalf("Want V=4 E=4, got V=%d E=%d", wg.V(), wg.E())
+	}
+
+	var buf bytes.Buffer
+	if err := WriteWeightGraph(&buf, &wg); err != nil {
+		t.Fatalf("Couldn't write graph, %v", err)
+	}
+	got, err := ReadWeightGraph(&buf)
+	if err != nil {
+		t.Fatalf("Couldn't read graph, %v", err)
+	}
+	if got.V() != wg.V() || got.E() != wg.E() {
+		t.Fatalf("Want V=%d E=%d, got V=%d E=%d", wg.V(), wg.E(), got.V(), got.E())
+	}
+
+	want := make(map[Edge]bool)
+	for v := 0; v < wg.V(); v++ {
+		for _, e := range wg.Adj(v) {
+			want[e] = true
+		}
+	}
+	for v := 0; v < got.V(); v++ {
+		for _, e := range got.Adj(v) {
+			if !want[e] && !want[NewEdge(e.to, e.from, e.weight)] {
+				t.Errorf("Unexpected edge %#v", &e)
+			}
+		}
+	}
+}
+
+func TestWriteWeightDigraphRoundTrip(t *testing.T) {
+	wd, err := ReadWeightDigraph(strings.NewReader(tinyEWD))
+	if err != nil {
+		t.Fatalf("Couldn't read digraph, %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteWeightDigraph(&buf, &wd); err != nil {
+		t.Fatalf("Couldn't write digraph, %v", err)
+	}
+	got, err := ReadWeightDigraph(&buf)
+	if err != nil {
+		t.Fatalf("Couldn't read digraph, %v", err)
+	}
+
+	wantEdges, gotEdges := wd.Edges(), got.Edges()
+	if len(wantEdges) != len(gotEdges) {
+		t.Fatalf("Want %d edges, got %d", len(wantEdges), len(gotEdges))
+	}
+	for i := range wantEdges {
+		if wantEdges[i] != gotEdges[i] {
+			t.Errorf("Want edge %#v, got %#v", &wantEdges[i], &gotEdges[i])
+		}
+	}
+}
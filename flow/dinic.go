@@ -0,0 +1,105 @@
+package flow
+
+import (
+	"math"
+)
+
+// BuildDinic finds the maximum flow from s to t in flow network fn using
+// Dinic's algorithm, which saturates a blocking flow of the level graph at
+// every phase.  This is O(V^2 E), and O(E sqrt(V)) on unit capacity
+// networks.  fn is left untouched.  It returns an error if s or t are
+// invalid, or if an edge has a negative capacity.
+func BuildDinic(fn *FlowNetwork, s, t int) (MaxFlow, error) {
+	if err := checkNetwork(fn, s, t); err != nil {
+		return nil, err
+	}
+	residual := fn.clone()
+
+	value := 0.0
+	level := make([]int, residual.V())
+	next := make([]int, residual.V())
+
+	for buildLevels(residual, s, t, level) {
+		for v := range next {
+			next[v] = 0
+		}
+		value += blockingFlow(residual, s, t, level, next)
+	}
+
+	return newMaxFlow(residual, s, value), nil
+}
+
+// buildLevels sets the distance of every vertex from s in the residual
+// network fn, or -1 if it is unreachable.  It tells if t is reachable.
+func buildLevels(fn *FlowNetwork, s, t int, level []int) bool {
+	for v := range level {
+		level[v] = -1
+	}
+
+	level[s] = 0
+	queue := []int{s}
+	for len(queue) != 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, e := range fn.Adj(v) {
+			w := e.Other(v)
+			if level[w] == -1 && e.ResidualCapacityTo(w) > floatingPointEpsilon {
+				level[w] = level[v] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return level[t] != -1
+}
+
+// blockingFlow saturates paths going from s to t one level at a time, until
+// there are none left.  next[v] is the index of the first edge of v that
+// hasn't been found to be a dead end yet.
+func blockingFlow(fn *FlowNetwork, s, t int, level, next []int) float64 {
+	total := 0.0
+	var path []*FlowEdge
+	v := s
+
+	for {
+		if v == t {
+			bottle := math.Inf(1)
+			for u, i := s, 0; i < len(path); i++ {
+				u = path[i].Other(u)
+				bottle = math.Min(bottle, path[i].ResidualCapacityTo(u))
+			}
+			for u, i := s, 0; i < len(path); i++ {
+				u = path[i].Other(u)
+				path[i].AddResidualFlowTo(u, bottle)
+			}
+			total += bottle
+			path = path[:0]
+			v = s
+			continue
+		}
+
+		advanced := false
+		adj := fn.Adj(v)
+		for ; next[v] < len(adj); next[v]++ {
+			e := adj[next[v]]
+			w := e.Other(v)
+			if level[w] == level[v]+1 && e.ResidualCapacityTo(w) > floatingPointEpsilon {
+				path = append(path, e)
+				v = w
+				advanced = true
+				break
+			}
+		}
+		if advanced {
+			continue
+		}
+
+		// v is a dead end, retreat to the vertex we came from
+		if v == s {
+			return total
+		}
+		last := path[len(path)-1]
+		path = path[:len(path)-1]
+		v = last.Other(v)
+		next[v]++
+	}
+}
@@ -0,0 +1,59 @@
+package flow
+
+import (
+	"math"
+)
+
+// BuildEdmondsKarp finds the maximum flow from s to t in flow network fn
+// using the Edmonds-Karp algorithm, which augments the flow along shortest
+// paths found by breadth-first search.  This is O(VE^2).  fn is left
+// untouched.  It returns an error if s or t are invalid, or if an edge has
+// a negative capacity.
+func BuildEdmondsKarp(fn *FlowNetwork, s, t int) (MaxFlow, error) {
+	if err := checkNetwork(fn, s, t); err != nil {
+		return nil, err
+	}
+	residual := fn.clone()
+
+	value := 0.0
+	edgeTo := make([]*FlowEdge, residual.V())
+	marked := make([]bool, residual.V())
+
+	for hasAugmentingPath(residual, s, t, edgeTo, marked) {
+		bottle := math.Inf(1)
+		for v := t; v != s; v = edgeTo[v].Other(v) {
+			bottle = math.Min(bottle, edgeTo[v].ResidualCapacityTo(v))
+		}
+		for v := t; v != s; v = edgeTo[v].Other(v) {
+			edgeTo[v].AddResidualFlowTo(v, bottle)
+		}
+		value += bottle
+	}
+
+	return newMaxFlow(residual, s, value), nil
+}
+
+// hasAugmentingPath does a breadth-first search of the residual network fn,
+// recording in edgeTo the shortest path from s to every vertex it reaches.
+func hasAugmentingPath(fn *FlowNetwork, s, t int, edgeTo []*FlowEdge, marked []bool) bool {
+	for v := range marked {
+		marked[v] = false
+		edgeTo[v] = nil
+	}
+
+	marked[s] = true
+	queue := []int{s}
+	for len(queue) != 0 && !marked[t] {
+		v := queue[0]
+		queue = queue[1:]
+		for _, e := range fn.Adj(v) {
+			w := e.Other(v)
+			if !marked[w] && e.ResidualCapacityTo(w) > floatingPointEpsilon {
+				edgeTo[w] = e
+				marked[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+	return marked[t]
+}
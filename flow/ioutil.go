@@ -0,0 +1,51 @@
+package flow
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type networkScanner struct {
+	*bufio.Scanner
+}
+
+func newNetworkScanner(rd io.Reader) *networkScanner {
+	ns := networkScanner{bufio.NewScanner(rd)}
+	ns.Scanner.Split(bufio.ScanWords)
+	return &ns
+}
+
+func (n *networkScanner) NextInt() (int, error) {
+	if n.Scan() {
+		return strconv.Atoi(n.Text())
+	}
+	if err := n.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("couldn't scan")
+}
+
+func (n *networkScanner) NextFloat() (float64, error) {
+	if n.Scan() {
+		return strconv.ParseFloat(n.Text(), 64)
+	}
+	if err := n.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("couldn't scan")
+}
+
+func (n *networkScanner) NextEdge() (from int, to int, capacity float64, err error) {
+	from, err = n.NextInt()
+	if err != nil {
+		return
+	}
+	to, err = n.NextInt()
+	if err != nil {
+		return
+	}
+	capacity, err = n.NextFloat()
+	return
+}
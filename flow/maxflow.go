@@ -0,0 +1,95 @@
+package flow
+
+import (
+	"fmt"
+)
+
+// MaxFlow is a maximum flow from a source to a sink in a flow network, along
+// with the minimum cut that certifies it.
+type MaxFlow interface {
+	// Value is the total flow going from the source to the sink
+	Value() float64
+	// Edges are the edges of the network, in the order they were added,
+	// carrying their part of the maximum flow
+	Edges() []FlowEdge
+	// InCut tells if v is on the source side of the minimum cut
+	InCut(v int) bool
+	// Cut is the source side of the minimum cut, in increasing order
+	Cut() []int
+}
+
+type maxFlow struct {
+	fn    *FlowNetwork
+	value float64
+	inCut []bool
+}
+
+// checkNetwork validates what a maximum flow algorithm gets as input
+func checkNetwork(fn *FlowNetwork, s, t int) error {
+	if s < 0 || s >= fn.V() {
+		return fmt.Errorf("source %d is not a vertex", s)
+	}
+	if t < 0 || t >= fn.V() {
+		return fmt.Errorf("sink %d is not a vertex", t)
+	}
+	if s == t {
+		return fmt.Errorf("source and sink are both %d", s)
+	}
+	for _, e := range fn.Edges() {
+		if e.Capacity() < 0 {
+			return fmt.Errorf("edge %#v has negative capacity", e)
+		}
+	}
+	return nil
+}
+
+// newMaxFlow finds the minimum cut of network fn, once it carries a maximum
+// flow, by marking the vertices reachable from s in its residual network.
+func newMaxFlow(fn *FlowNetwork, s int, value float64) maxFlow {
+	m := maxFlow{
+		fn:    fn,
+		value: value,
+		inCut: make([]bool, fn.V()),
+	}
+
+	m.inCut[s] = true
+	queue := []int{s}
+	for len(queue) != 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, e := range fn.Adj(v) {
+			w := e.Other(v)
+			if !m.inCut[w] && e.ResidualCapacityTo(w) > floatingPointEpsilon {
+				m.inCut[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+	return m
+}
+
+func (m maxFlow) Value() float64 {
+	return m.value
+}
+
+func (m maxFlow) Edges() []FlowEdge {
+	edges := make([]FlowEdge, m.fn.E())
+	for i, e := range m.fn.Edges() {
+		edges[i] = *e
+	}
+	return edges
+}
+
+func (m maxFlow) InCut(v int) bool {
+	return m.inCut[v]
+}
+
+func (m maxFlow) Cut() []int {
+	var cut []int
+	for v, in := range m.inCut {
+		if in {
+			cut = append(cut, v)
+		}
+	}
+	return cut
+}
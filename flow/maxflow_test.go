@@ -0,0 +1,128 @@
+package flow
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+type maxFlowBuilder func(*FlowNetwork, int, int) (MaxFlow, error)
+
+var maxFlowBuilders = map[string]maxFlowBuilder{
+	"EdmondsKarp": BuildEdmondsKarp,
+	"Dinic":       BuildDinic,
+}
+
+func TestMaxFlowMatchesKnownOutput(t *testing.T) {
+	for name, build := range maxFlowBuilders {
+		fn, err := ReadFlowNetwork(strings.NewReader(tinyFN))
+		if err != nil {
+			t.Fatalf("Couldn't read flow network, %v", err)
+		}
+
+		mf, err := build(&fn, 0, 5)
+		if err != nil {
+			t.Fatalf("%s: couldn't build max flow, %v", name, err)
+		}
+
+		if mf.Value() != 4.0 {
+			t.Errorf("%s: expected max flow of 4, was %f", name, mf.Value())
+		}
+		checkFlow(t, name, &fn, mf, 0, 5)
+
+		cut := mf.Cut()
+		if len(cut) != 2 || cut[0] != 0 || cut[1] != 2 {
+			t.Errorf("%s: expected min cut [0 2], was %v", name, cut)
+		}
+
+		for _, e := range fn.Edges() {
+			if e.Flow() != 0 {
+				t.Errorf("%s: input network should be left untouched, %#v", name, e)
+			}
+		}
+	}
+}
+
+func TestMaxFlowAlgorithmsAgree(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 50; i++ {
+		v := 2 + r.Intn(30)
+		fn := NewFlowNetwork(v)
+		for e := 0; e < 4*v; e++ {
+			fn.AddEdge(NewFlowEdge(r.Intn(v), r.Intn(v), float64(r.Intn(20))))
+		}
+
+		var values []float64
+		for name, build := range maxFlowBuilders {
+			mf, err := build(&fn, 0, v-1)
+			if err != nil {
+				t.Fatalf("%s: couldn't build max flow, %v", name, err)
+			}
+			checkFlow(t, name, &fn, mf, 0, v-1)
+			values = append(values, mf.Value())
+		}
+		if values[0] != values[1] {
+			t.Fatalf("Algorithms disagree on max flow, %v", values)
+		}
+	}
+}
+
+func TestMaxFlowRefusesBadInput(t *testing.T) {
+	fn := NewFlowNetwork(2)
+	fn.AddEdge(NewFlowEdge(0, 1, 1))
+	bad := NewFlowNetwork(2)
+	bad.AddEdge(NewFlowEdge(0, 1, -1))
+
+	for name, build := range maxFlowBuilders {
+		if _, err := build(&fn, 0, 0); err == nil {
+			t.Errorf("%s: should refuse source equal to sink", name)
+		}
+		if _, err := build(&fn, 0, 2); err == nil {
+			t.Errorf("%s: should refuse a sink out of range", name)
+		}
+		if _, err := build(&bad, 0, 1); err == nil {
+			t.Errorf("%s: should refuse a negative capacity", name)
+		}
+	}
+}
+
+// checkFlow verifies that mf is a feasible flow whose value matches the
+// capacity of its cut, which proves it is maximum.
+func checkFlow(t *testing.T, name string, fn *FlowNetwork, mf MaxFlow, s, sink int) {
+	const eps = 1e-9
+
+	excess := make([]float64, fn.V())
+	cutCapacity := 0.0
+	for _, e := range mf.Edges() {
+		if e.Flow() < -eps || e.Flow() > e.Capacity()+eps {
+			t.Errorf("%s: flow out of bounds on %#v", name, &e)
+		}
+		excess[e.From()] -= e.Flow()
+		excess[e.To()] += e.Flow()
+		if mf.InCut(e.From()) && !mf.InCut(e.To()) {
+			cutCapacity += e.Capacity()
+		}
+	}
+
+	for v := range excess {
+		if v != s && v != sink && math.Abs(excess[v]) > eps {
+			t.Errorf("%s: flow isn't conserved at %d, excess=%f", name, v, excess[v])
+		}
+	}
+	if math.Abs(excess[sink]-mf.Value()) > eps {
+		t.Errorf("%s: sink receives %f but value is %f", name, excess[sink], mf.Value())
+	}
+
+	if !mf.InCut(s) || mf.InCut(sink) {
+		t.Errorf("%s: cut should separate source from sink, %v", name, mf.Cut())
+	}
+	if math.Abs(cutCapacity-mf.Value()) > eps {
+		t.Errorf("%s: cut capacity %f doesn't match value %f", name, cutCapacity, mf.Value())
+	}
+	for _, v := range mf.Cut() {
+		if !mf.InCut(v) {
+			t.Errorf("%s: Cut and InCut disagree on %d", name, v)
+		}
+	}
+}
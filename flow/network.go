@@ -0,0 +1,194 @@
+// Package flow implements flow networks and algorithms computing their
+// maximum flow and minimum cut.
+package flow
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// floatingPointEpsilon is the residual capacity under which an edge is
+// considered saturated, to protect against rounding errors.
+const floatingPointEpsilon = 1e-11
+
+// FlowNetwork is a directed graph whose edges have a capacity and carry
+// some flow.
+type FlowNetwork struct {
+	adj   [][]*FlowEdge
+	edges []*FlowEdge
+}
+
+// NewFlowNetwork creates an empty flow network with v vertices
+func NewFlowNetwork(v int) FlowNetwork {
+	return FlowNetwork{
+		adj: make([][]*FlowEdge, v),
+	}
+}
+
+// ReadFlowNetwork constructs a flow network from the io.Reader expecting
+// to find data formed such as:
+//   v
+//   e
+//   a b c0
+//   c d c1
+//   ...
+//   y z cN
+// where `v` is the vertex count, `e` the number of edges and `a`, `b`, `c`,
+// `d`, ..., `y` and `z` are edges from `a` to `b`, `c` to `d`, ..., and
+// `y` to `z` respectively, and `cN` is the capacity of that edge.
+func ReadFlowNetwork(input io.Reader) (FlowNetwork, error) {
+	scan := newNetworkScanner(input)
+
+	v, err := scan.NextInt()
+	if err != nil {
+		return FlowNetwork{}, fmt.Errorf("failed reading vertex count, %v", err)
+	}
+
+	fn := NewFlowNetwork(v)
+
+	e, err := scan.NextInt()
+	if err != nil {
+		return FlowNetwork{}, fmt.Errorf("failed reading edge count, %v", err)
+	}
+
+	for i := 0; i < e; i++ {
+		from, to, capacity, err := scan.NextEdge()
+		if err != nil {
+			return fn, fmt.Errorf("failed at edge line=%d, %v", i, err)
+		}
+		fn.AddEdge(NewFlowEdge(from, to, capacity))
+	}
+
+	return fn, nil
+}
+
+// AddEdge adds edge e to this network.  The edge is adjacent to both its
+// vertices, since flow can be pushed back against it.
+func (fn *FlowNetwork) AddEdge(e FlowEdge) {
+	edge := &e
+	fn.adj[e.from] = append(fn.adj[e.from], edge)
+	fn.adj[e.to] = append(fn.adj[e.to], edge)
+	fn.edges = append(fn.edges, edge)
+}
+
+// Adj gives the edges incident to v, both going out of and into v
+func (fn *FlowNetwork) Adj(v int) []*FlowEdge {
+	return fn.adj[v]
+}
+
+// Edges gives all the edges in this network, in the order they were added
+func (fn *FlowNetwork) Edges() []*FlowEdge {
+	return fn.edges
+}
+
+// V is the number of vertices
+func (fn *FlowNetwork) V() int {
+	return len(fn.adj)
+}
+
+// E is the number of edges
+func (fn *FlowNetwork) E() int {
+	return len(fn.edges)
+}
+
+// GoString represents this flow network
+func (fn *FlowNetwork) GoString() string {
+	var output bytes.Buffer
+
+	do := func(n int, err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	for _, e := range fn.edges {
+		do(output.WriteString(e.GoString()))
+		do(output.WriteRune('\n'))
+	}
+	return output.String()
+}
+
+// clone gives a copy of this network whose flows can be changed without
+// affecting this one.
+func (fn *FlowNetwork) clone() *FlowNetwork {
+	c := NewFlowNetwork(fn.V())
+	for _, e := range fn.edges {
+		c.AddEdge(*e)
+	}
+	return &c
+}
+
+// FlowEdge is a capacitated edge in a flow network
+type FlowEdge struct {
+	from     int
+	to       int
+	capacity float64
+	flow     float64
+}
+
+// NewFlowEdge creates an edge from v to w with the given capacity, carrying
+// no flow
+func NewFlowEdge(v, w int, capacity float64) FlowEdge {
+	return FlowEdge{from: v, to: w, capacity: capacity}
+}
+
+// From is the tail vertex of this edge
+func (e *FlowEdge) From() int {
+	return e.from
+}
+
+// To is the head vertex of this edge
+func (e *FlowEdge) To() int {
+	return e.to
+}
+
+// Other tells the other end of this edge, from v's perspective.
+func (e *FlowEdge) Other(v int) int {
+	if e.from == v {
+		return e.to
+	}
+	return e.from
+}
+
+// Capacity is the maximum flow this edge can carry
+func (e *FlowEdge) Capacity() float64 {
+	return e.capacity
+}
+
+// Flow is the flow this edge carries
+func (e *FlowEdge) Flow() float64 {
+	return e.flow
+}
+
+// ResidualCapacityTo is how much more flow can go toward v through this
+// edge: the unused capacity if v is the head of the edge, or the flow that
+// can be pushed back if v is its tail.
+func (e *FlowEdge) ResidualCapacityTo(v int) float64 {
+	if v == e.from {
+		return e.flow
+	}
+	return e.capacity - e.flow
+}
+
+// AddResidualFlowTo pushes delta more flow toward v through this edge.
+func (e *FlowEdge) AddResidualFlowTo(v int, delta float64) {
+	if v == e.from {
+		e.flow -= delta
+	} else {
+		e.flow += delta
+	}
+
+	// round off to the capacity or zero when very close to it
+	if e.flow < floatingPointEpsilon && e.flow > -floatingPointEpsilon {
+		e.flow = 0
+	}
+	if d := e.flow - e.capacity; d < floatingPointEpsilon && d > -floatingPointEpsilon {
+		e.flow = e.capacity
+	}
+}
+
+// GoString represents this edge with its flow and capacity
+func (e *FlowEdge) GoString() string {
+	return fmt.Sprintf("%d->%d %.5f/%.5f", e.from, e.to, e.flow, e.capacity)
+}
@@ -0,0 +1,69 @@
+package flow
+
+import (
+	"strings"
+	"testing"
+)
+
+// The tinyFN network from Algorithms 4th Ed.
+const tinyFN = `6
+8
+0 1 2.0
+0 2 3.0
+1 3 3.0
+1 4 1.0
+2 3 1.0
+2 4 1.0
+3 5 2.0
+4 5 3.0
+`
+
+func TestFlowNetworkFromReader(t *testing.T) {
+	fn, err := ReadFlowNetwork(strings.NewReader(tinyFN))
+	if err != nil {
+		t.Fatalf("Couldn't read flow network, %v", err)
+	}
+	fn.GoString()
+
+	if fn.V() != 6 {
+		t.Errorf("Vertex count, want %d got %d", 6, fn.V())
+	}
+	if fn.E() != 8 {
+		t.Errorf("Edge count, want %d got %d", 8, fn.E())
+	}
+	// 1 has one edge in and two out
+	if len(fn.Adj(1)) != 3 {
+		t.Errorf("Expected 3 edges incident to 1, but was %v", fn.Adj(1))
+	}
+}
+
+func TestFlowNetworkFromBadReader(t *testing.T) {
+	for _, input := range []string{"", "6", "6\n8\n0 1", "6\n1\n0 1 x"} {
+		if _, err := ReadFlowNetwork(strings.NewReader(input)); err == nil {
+			t.Errorf("Should have failed reading %q", input)
+		}
+	}
+}
+
+func TestFlowEdgeResidualCapacity(t *testing.T) {
+	e := NewFlowEdge(0, 1, 3.0)
+	if e.ResidualCapacityTo(1) != 3.0 || e.ResidualCapacityTo(0) != 0.0 {
+		t.Fatalf("Empty edge should only have forward residual capacity, %#v", &e)
+	}
+
+	e.AddResidualFlowTo(1, 2.0)
+	if e.Flow() != 2.0 {
+		t.Errorf("Expected flow of 2, was %f", e.Flow())
+	}
+	if e.ResidualCapacityTo(1) != 1.0 || e.ResidualCapacityTo(0) != 2.0 {
+		t.Errorf("Expected residual capacities 1 forward and 2 backward, %#v", &e)
+	}
+
+	e.AddResidualFlowTo(0, 0.5)
+	if e.Flow() != 1.5 {
+		t.Errorf("Expected flow of 1.5 after pushing back, was %f", e.Flow())
+	}
+	if e.Other(0) != 1 || e.Other(1) != 0 {
+		t.Errorf("Other should give the opposite vertex")
+	}
+}
@@ -0,0 +1,285 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Number is a type that weights can have.  Integer weights are exact in the
+// algorithms written for them, BuildKruskalMSTOf in package mst and
+// BuildDijkstraOf in package path, so that the weight of a tree or a path
+// doesn't suffer from float rounding.  Other algorithms go through Float64,
+// which is only exact for small enough weights.  Custom types are fine, as
+// long as they are numbers underneath.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// WeightGraphOf is WeightGraph, with weights of type W instead of float64.
+type WeightGraphOf[W Number] struct {
+	adj [][]EdgeOf[W]
+	e   int
+}
+
+// NewWeightGraphOf creates an empty graph with v vertices and weights of
+// type W
+func NewWeightGraphOf[W Number](v int) WeightGraphOf[W] {
+	return WeightGraphOf[W]{
+		adj: make([][]EdgeOf[W], v),
+		e:   0,
+	}
+}
+
+// AddEdge adds weighted edge e to this graph
+func (wg *WeightGraphOf[W]) AddEdge(e EdgeOf[W]) {
+	wg.adj[e.from] = append(wg.adj[e.from], e)
+	wg.adj[e.to] = append(wg.adj[e.to], e)
+	wg.e++
+}
+
+// AddVertex adds an isolated vertex to this graph, returning it
+func (wg *WeightGraphOf[W]) AddVertex() int {
+	wg.adj = append(wg.adj, nil)
+	return len(wg.adj) - 1
+}
+
+// Adj gives the edges incident to v
+func (wg *WeightGraphOf[W]) Adj(v int) []EdgeOf[W] {
+	return wg.adj[v]
+}
+
+// Edges gives all the edges in this graph
+func (wg *WeightGraphOf[W]) Edges() []EdgeOf[W] {
+	var edges []EdgeOf[W]
+	other := func(v int, e EdgeOf[W]) int { return e.Other(v) }
+	eachUndirectedEdge(wg.V(), wg.Adj, other, func(_, _ int, e EdgeOf[W]) {
+		edges = append(edges, e)
+	})
+	return edges
+}
+
+// V is the number of vertices
+func (wg *WeightGraphOf[W]) V() int {
+	return len(wg.adj)
+}
+
+// E is the number of edges
+func (wg *WeightGraphOf[W]) E() int {
+	return wg.e
+}
+
+// Float64 is a copy of this graph with float64 weights, for the algorithms
+// that work on a WeightGraph.  This loses precision: a float64 only holds
+// integers up to 2^53 exactly, so larger weights are rounded, and so are
+// sums of weights that the algorithms compute beyond that.
+func (wg *WeightGraphOf[W]) Float64() WeightGraph {
+	f := NewWeightGraph(wg.V())
+	for _, e := range wg.Edges() {
+		f.AddEdge(NewEdge(e.from, e.to, float64(e.weight)))
+	}
+	return f
+}
+
+// Graph is a copy of this graph without weights, for the algorithms that
+// work on a Graph.
+func (wg *WeightGraphOf[W]) Graph() Ungraph {
+	g := NewGraph(wg.V())
+	for _, e := range wg.Edges() {
+		g.AddEdge(e.from, e.to)
+	}
+	return g
+}
+
+// GoString represents this weighted graph
+func (wg *WeightGraphOf[W]) GoString() string {
+	var output bytes.Buffer
+
+	do := func(n int, err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	for v := 0; v < wg.V(); v++ {
+		for _, w := range wg.Adj(v) {
+			do(output.WriteString(w.GoString()))
+			do(output.WriteRune('\n'))
+		}
+	}
+	return output.String()
+}
+
+// EdgeOf is Edge, with a weight of type W instead of float64.
+type EdgeOf[W Number] struct {
+	weight W
+	from   int
+	to     int
+}
+
+// NewEdgeOf creates a weighted edge to be used by a WeightGraphOf
+func NewEdgeOf[W Number](v, w int, weight W) EdgeOf[W] {
+	return EdgeOf[W]{weight: weight, from: v, to: w}
+}
+
+// Less tells if this edge is less than the other edge
+func (e *EdgeOf[W]) Less(other EdgeOf[W]) bool {
+	return e.weight < other.weight
+}
+
+// Either returns either vertices of this edge.
+func (e *EdgeOf[W]) Either() int {
+	return e.from
+}
+
+// Other tells the other end of this edge, from v's perspective.
+func (e *EdgeOf[W]) Other(v int) int {
+	if e.from == v {
+		return e.to
+	}
+	return e.from
+}
+
+// Weight tells the weight of this edge
+func (e *EdgeOf[W]) Weight() W {
+	return e.weight
+}
+
+// GoString represents this edge in a directed, weighted fashion
+func (e *EdgeOf[W]) GoString() string {
+	return fmt.Sprintf("%d-%d %v", e.from, e.to, e.weight)
+}
+
+// WeightDigraphOf is EdgeWeightedDigraph, with weights of type W instead of
+// float64.
+type WeightDigraphOf[W Number] struct {
+	adj [][]DirectedEdgeOf[W]
+	e   int
+}
+
+// NewWeightDigraphOf creates an empty digraph with v vertices and weights of
+// type W
+func NewWeightDigraphOf[W Number](v int) WeightDigraphOf[W] {
+	return WeightDigraphOf[W]{
+		adj: make([][]DirectedEdgeOf[W], v),
+		e:   0,
+	}
+}
+
+// AddEdge adds weighted edge e to this digraph, going out of e.From()
+func (wd *WeightDigraphOf[W]) AddEdge(e DirectedEdgeOf[W]) {
+	wd.adj[e.from] = append(wd.adj[e.from], e)
+	wd.e++
+}
+
+// AddVertex adds an isolated vertex to this digraph, returning it
+func (wd *WeightDigraphOf[W]) AddVertex() int {
+	wd.adj = append(wd.adj, nil)
+	return len(wd.adj) - 1
+}
+
+// Adj gives the edges going out of v
+func (wd *WeightDigraphOf[W]) Adj(v int) []DirectedEdgeOf[W] {
+	return wd.adj[v]
+}
+
+// Edges gives all the edges in this digraph
+func (wd *WeightDigraphOf[W]) Edges() []DirectedEdgeOf[W] {
+	var edges []DirectedEdgeOf[W]
+	for v := 0; v < wd.V(); v++ {
+		edges = append(edges, wd.Adj(v)...)
+	}
+	return edges
+}
+
+// V is the number of vertices
+func (wd *WeightDigraphOf[W]) V() int {
+	return len(wd.adj)
+}
+
+// E is the number of edges
+func (wd *WeightDigraphOf[W]) E() int {
+	return wd.e
+}
+
+// Float64 is a copy of this digraph with float64 weights, for the
+// algorithms that work on an EdgeWeightedDigraph.  This loses precision: a
+// float64 only holds integers up to 2^53 exactly, so larger weights are
+// rounded, and so are sums of weights that the algorithms compute beyond
+// that.
+func (wd *WeightDigraphOf[W]) Float64() EdgeWeightedDigraph {
+	f := NewEdgeWeightedDigraph(wd.V())
+	for _, e := range wd.Edges() {
+		f.AddEdge(NewDirectedEdge(e.from, e.to, float64(e.weight)))
+	}
+	return f
+}
+
+// Digraph is a copy of this digraph without weights, for the algorithms
+// that work on a Digraph.
+func (wd *WeightDigraphOf[W]) Digraph() Digraph {
+	di := NewDigraph(wd.V())
+	for _, e := range wd.Edges() {
+		di.AddEdge(e.from, e.to)
+	}
+	return di
+}
+
+// GoString represents this weighted digraph
+func (wd *WeightDigraphOf[W]) GoString() string {
+	var output bytes.Buffer
+
+	do := func(n int, err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	for v := 0; v < wd.V(); v++ {
+		for _, w := range wd.Adj(v) {
+			do(output.WriteString(w.GoString()))
+			do(output.WriteRune('\n'))
+		}
+	}
+	return output.String()
+}
+
+// DirectedEdgeOf is DirectedEdge, with a weight of type W instead of
+// float64.
+type DirectedEdgeOf[W Number] struct {
+	weight W
+	from   int
+	to     int
+}
+
+// NewDirectedEdgeOf creates a weighted edge from v to w, to be used by a
+// WeightDigraphOf
+func NewDirectedEdgeOf[W Number](v, w int, weight W) DirectedEdgeOf[W] {
+	return DirectedEdgeOf[W]{weight: weight, from: v, to: w}
+}
+
+// Less tells if this edge is less than the other edge
+func (e *DirectedEdgeOf[W]) Less(other DirectedEdgeOf[W]) bool {
+	return e.weight < other.weight
+}
+
+// From is the tail vertex of this edge
+func (e *DirectedEdgeOf[W]) From() int {
+	return e.from
+}
+
+// To is the head vertex of this edge
+func (e *DirectedEdgeOf[W]) To() int {
+	return e.to
+}
+
+// Weight tells the weight of this edge
+func (e *DirectedEdgeOf[W]) Weight() W {
+	return e.weight
+}
+
+// GoString represents this edge in a directed, weighted fashion
+func (e *DirectedEdgeOf[W]) GoString() string {
+	return fmt.Sprintf("%d->%d %v", e.from, e.to, e.weight)
+}
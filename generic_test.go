@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"testing"
+)
+
+// cents is a custom weight type, such as for billing graphs.
+type cents int64
+
+func TestWeightGraphOfEdges(t *testing.T) {
+	wg := NewWeightGraphOf[cents](3)
+	wg.AddEdge(NewEdgeOf[cents](0, 1, 150))
+	wg.AddEdge(NewEdgeOf[cents](1, 2, 25))
+	wg.AddEdge(NewEdgeOf[cents](2, 2, 7))
+
+	if wg.V() != 3 || wg.E() != 3 {
+		t.Fatalf("Want V=3 E=3, got V=%d E=%d", wg.V(), wg.E())
+	}
+	edges := wg.Edges()
+	if len(edges) != 3 {
+		t.Fatalf("Should list every edge once, got\n%s", wg.GoString())
+	}
+	total := cents(0)
+	for _, e := range edges {
+		total += e.Weight()
+	}
+	if total != 182 {
+		t.Errorf("Edges should weigh 182, got %d", total)
+	}
+
+	v := wg.AddVertex()
+	wg.AddEdge(NewEdgeOf[cents](v, 0, 1))
+	if wg.V() != 4 || len(wg.Adj(0)) != 2 {
+		t.Errorf("Should have connected new vertex %d, adj(0)=%v", v, wg.Adj(0))
+	}
+
+	f := wg.Float64()
+	if f.V() != wg.V() || len(f.Adj(2)) != len(wg.Adj(2)) {
+		t.Errorf("Float64 copy should have the same edges, got\n%s", f.GoString())
+	}
+	g := wg.Graph()
+	if g.V() != wg.V() || g.E() != wg.E() || len(g.Adj(2)) != 3 {
+		t.Errorf("Unweighted copy should have the same edges, got\n%s", g.GoString())
+	}
+}
+
+func TestWeightDigraphOfEdges(t *testing.T) {
+	wd := NewWeightDigraphOf[uint8](2)
+	wd.AddEdge(NewDirectedEdgeOf[uint8](0, 1, 255))
+	wd.AddEdge(NewDirectedEdgeOf[uint8](1, 1, 1))
+
+	if wd.V() != 2 || wd.E() != 2 || len(wd.Edges()) != 2 {
+		t.Fatalf("Want V=2 E=2, got V=%d E=%d", wd.V(), wd.E())
+	}
+	if e := wd.Adj(0)[0]; e.From() != 0 || e.To() != 1 || e.Weight() != 255 {
+		t.Errorf("Unexpected edge %#v", &e)
+	}
+
+	f := wd.Float64()
+	if e := f.Adj(0)[0]; e.Weight() != 255 {
+		t.Errorf("Float64 copy should keep the weight, got %#v", &e)
+	}
+	di := wd.Digraph()
+	if di.E() != 2 || !di.HasEdge(1, 1) {
+		t.Errorf("Unweighted copy should have the same edges, got\n%s", di.GoString())
+	}
+}
+
+func TestKeyedGraphs(t *testing.T) {
+	type account struct{ bank, id string }
+	alice, bob, carol := account{"b1", "alice"}, account{"b2", "bob"}, account{"b1", "carol"}
+
+	kg := NewKeyedGraph[account, cents]()
+	kg.AddEdge(alice, bob, 100)
+	kg.AddEdge(bob, carol, 5)
+	kg.AddEdge(alice, bob, 1)
+
+	if kg.Graph().V() != 3 || kg.Graph().E() != 3 {
+		t.Fatalf("Want V=3 E=3, got V=%d E=%d", kg.Graph().V(), kg.Graph().E())
+	}
+	if !kg.Contains(carol) || kg.Contains(account{"b2", "carol"}) {
+		t.Errorf("Should only contain the keys added")
+	}
+	if kg.Name(kg.Index(bob)) != bob || kg.AddVertex(bob) != kg.Index(bob) {
+		t.Errorf("Keys and indices should map back to each other")
+	}
+	if len(kg.Graph().Adj(kg.Index(bob))) != 3 {
+		t.Errorf("bob should have 3 edges, got %v", kg.Graph().Adj(kg.Index(bob)))
+	}
+
+	kd := NewKeyedDigraph[string, int]()
+	kd.AddEdge("a", "b", -3)
+	dave := kd.AddVertex("dave")
+	if kd.Digraph().V() != 3 || kd.Digraph().E() != 1 || len(kd.Digraph().Adj(dave)) != 0 {
+		t.Errorf("Want isolated dave among 3 vertices, got\n%s", kd.Digraph().GoString())
+	}
+	if e := kd.Digraph().Adj(kd.Index("a"))[0]; e.To() != kd.Index("b") || e.Weight() != -3 {
+		t.Errorf("Unexpected edge %#v", &e)
+	}
+}
@@ -61,61 +61,209 @@ func AvgDegree(g Graph) float64 {
 	return 2.0 * e / v
 }
 
-// HasCycle returns if graph g has any cycle.
+// HasCycle returns if graph g has any cycle.  For a directed graph, it tells
+// if some edge goes back to a vertex that was already visited.
 func HasCycle(g Graph) bool {
+	_, found := undirectedCycle(g)
+	return found
+}
+
+// UndirectedCycle returns a cycle in undirected graph g, if there is one.  A
+// self-loop and two parallel edges are cycles.  The first and last vertices
+// are the same, as in DirectedCycle.  g must be undirected, such as an
+// Ungraph or an undirected CSRGraph or MappedGraph: given a digraph, it
+// terminates but may not find a cycle.
+func UndirectedCycle(g Graph) []int {
+	cycle, _ := undirectedCycle(g)
+	return cycle
+}
+
+// undirectedCycle looks for an edge going back to a visited vertex in g,
+// giving the cycle it closes when that vertex is an ancestor of the current
+// one, which is always the case in an undirected graph.
+func undirectedCycle(g Graph) (cycle []int, found bool) {
 
 	marked := make([]bool, g.V())
-	hasCycle := false
-	var dfs func(v, u int)
-
-	dfs = func(v, u int) {
-		marked[v] = true
-		for _, adj := range g.Adj(v) {
-			if !marked[adj] {
-				dfs(adj, v)
-			} else if adj != u {
-				hasCycle = true
-			}
+	onStack := make([]bool, g.V())
+	edgeTo := make([]int, g.V())
+
+	var stack []dfsFrame
+
+	for s := 0; s < g.V() && !found; s++ {
+		if marked[s] {
+			continue
 		}
-	}
+		marked[s] = true
+		onStack[s] = true
+		stack = append(stack, dfsFrame{v: s, parent: -1})
+
+		for len(stack) != 0 && !found {
+			f := &stack[len(stack)-1]
+			v := f.v
+			adj := g.Adj(v)
+			if f.next == len(adj) {
+				onStack[v] = false
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			w := adj[f.next]
+			f.next++
 
-	for s := 0; s < g.V(); s++ {
-		if !marked[s] {
-			dfs(s, s)
+			if w == f.parent && !f.skippedParent {
+				// the edge we came from, but only once, since a
+				// parallel edge back to the parent closes a cycle
+				f.skippedParent = true
+			} else if !marked[w] {
+				marked[w] = true
+				onStack[w] = true
+				edgeTo[w] = v
+				stack = append(stack, dfsFrame{v: w, parent: v})
+			} else {
+				found = true
+				if !onStack[w] {
+					// only in a directed graph, w is in another
+					// branch of the search and there is no tree path
+					// from w to v
+					break
+				}
+				cycle = append(cycle, w)
+				for x := v; x != w; x = edgeTo[x] {
+					cycle = append(cycle, x)
+				}
+				cycle = append(cycle, w)
+			}
 		}
 	}
-	return hasCycle
+
+	return cycle, found
 }
 
 // IsBipartite returns if every vertex in graph g can be colored with only two
 // colors, while never sharing the same color of an adjacent vertex
 func IsBipartite(g Graph) bool {
+	return bipartition(g).IsBipartite()
+}
+
+// Bipartition is a two-coloring of the vertices of a graph, such that no edge
+// joins two vertices of the same color.  If the graph has no such coloring,
+// the bipartition holds an odd cycle of the graph to prove it.
+type Bipartition struct {
+	color    []int
+	conflict bool
+	cycle    []int
+}
+
+// NewBipartition tries to two-color undirected graph g with a depth-first
+// search, giving either its bipartition or one of its odd cycles.  g must be
+// undirected, such as an Ungraph or an undirected CSRGraph or MappedGraph:
+// given a digraph, it still tells if g can be two-colored, but may not find
+// an odd cycle to prove it can't.
+func NewBipartition(g Graph) Bipartition {
+	return bipartition(g)
+}
+
+// bipartition two-colors g, giving the odd cycle closed by the first edge
+// between two vertices of the same color when it goes back to an ancestor,
+// which is always the case in an undirected graph.
+func bipartition(g Graph) Bipartition {
 	marked := make([]bool, g.V())
-	color := make([]bool, g.V())
+	onStack := make([]bool, g.V())
+	edgeTo := make([]int, g.V())
+	b := Bipartition{color: make([]int, g.V())}
+
+	var stack []dfsFrame
 
-	isTwoColor := true
+	for s := 0; s < g.V() && !b.conflict; s++ {
+		if marked[s] {
+			continue
+		}
+		marked[s] = true
+		onStack[s] = true
+		stack = append(stack, dfsFrame{v: s})
 
-	var dfs func(v int)
+		for len(stack) != 0 && !b.conflict {
+			f := &stack[len(stack)-1]
+			v := f.v
+			adj := g.Adj(v)
+			if f.next == len(adj) {
+				onStack[v] = false
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			w := adj[f.next]
+			f.next++
 
-	dfs = func(v int) {
-		marked[v] = true
-		for _, adj := range g.Adj(v) {
-			if !marked[adj] {
-				color[adj] = !color[v]
-				dfs(adj)
-			} else if color[v] == color[adj] {
-				isTwoColor = false
+			if !marked[w] {
+				marked[w] = true
+				onStack[w] = true
+				edgeTo[w] = v
+				b.color[w] = 1 - b.color[v]
+				stack = append(stack, dfsFrame{v: w})
+			} else if b.color[v] == b.color[w] {
+				b.conflict = true
+				if !onStack[w] {
+					// only in a directed graph, there is no tree path
+					// from w to v to close the cycle with
+					break
+				}
+				b.cycle = append(b.cycle, w)
+				for x := v; x != w; x = edgeTo[x] {
+					b.cycle = append(b.cycle, x)
+				}
+				b.cycle = append(b.cycle, w)
 			}
 		}
 	}
 
-	for s := 0; s < g.V(); s++ {
-		if !marked[s] {
-			dfs(s)
+	return b
+}
+
+// IsBipartite tells if the graph could be two-colored.
+func (b Bipartition) IsBipartite() bool {
+	return !b.conflict
+}
+
+// Color of vertex v, either 0 or 1.  It panics if the graph isn't
+// bipartite.
+func (b Bipartition) Color(v int) int {
+	b.mustBeBipartite()
+	return b.color[v]
+}
+
+// Side gives the vertices of color c, in increasing order.  It panics if the
+// graph isn't bipartite.
+func (b Bipartition) Side(c int) []int {
+	b.mustBeBipartite()
+	var side []int
+	for v, color := range b.color {
+		if color == c {
+			side = append(side, v)
 		}
 	}
+	return side
+}
 
-	return isTwoColor
+// OddCycle returns a cycle of odd length if the graph isn't bipartite.  The
+// first and last vertices are the same, as in DirectedCycle.
+func (b Bipartition) OddCycle() []int {
+	return b.cycle
+}
+
+func (b Bipartition) mustBeBipartite() {
+	if !b.IsBipartite() {
+		panic("graph is not bipartite")
+	}
+}
+
+// dfsFrame is a vertex being visited by an iterative depth-first search,
+// along with the vertex it was reached from and the index of the next of its
+// adjacent vertices to look at.  Iterating with an explicit stack of frames
+// lets deep graphs be searched without growing the goroutine stack.
+type dfsFrame struct {
+	v             int
+	parent        int
+	next          int
+	skippedParent bool
 }
 
 func stringify(g Graph) string {
@@ -137,3 +285,56 @@ func stringify(g Graph) string {
 	}
 	return output.String()
 }
+
+// eachUndirectedEdge calls do once for every edge of an undirected graph of
+// n vertices, from its end v to its end w, with v <= w.  Every edge appears
+// in the adjacency lists of both its ends, and self-loops appear twice in
+// the list of their vertex.  other gives the vertex at the other end of an
+// entry of the adjacency list of v.
+func eachUndirectedEdge[E any](n int, adj func(v int) []E, other func(v int, e E) int, do func(v, w int, e E)) {
+	for v := 0; v < n; v++ {
+		selfLoops := 0
+		for _, e := range adj(v) {
+			w := other(v, e)
+			if w == v {
+				selfLoops++
+				if selfLoops%2 == 0 {
+					continue
+				}
+			}
+			if w >= v {
+				do(v, w, e)
+			}
+		}
+	}
+}
+
+// adjVertex is the other end of an entry of an unweighted adjacency list.
+func adjVertex(v, w int) int {
+	return w
+}
+
+// edgeOther is the other end of an entry of a weighted adjacency list.
+func edgeOther(v int, e Edge) int {
+	return e.Other(v)
+}
+
+// indexOf is the index of the last w in adj, or -1 if there is none.
+func indexOf(adj []int, w int) int {
+	for i := len(adj) - 1; i >= 0; i-- {
+		if adj[i] == w {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeLast removes the last w from adj, keeping the order of the others
+// and telling if there was one.
+func removeLast(adj []int, w int) ([]int, bool) {
+	i := indexOf(adj, w)
+	if i == -1 {
+		return adj, false
+	}
+	return append(adj[:i], adj[i+1:]...), true
+}
@@ -69,6 +69,135 @@ func TestGraphIsNotBipartite(t *testing.T) {
 	}
 }
 
+func TestBipartitionHasSides(t *testing.T) {
+	// 0-1-2-3 with 0-3 is an even cycle, 4 is alone
+	g := NewGraph(5)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 0)
+
+	b := NewBipartition(g)
+	if !b.IsBipartite() {
+		t.Fatalf("Graph should be bipartite, got odd cycle %v", b.OddCycle())
+	}
+	if len(b.OddCycle()) != 0 {
+		t.Errorf("Bipartite graph shouldn't have an odd cycle, got %v", b.OddCycle())
+	}
+
+	for v := 0; v < g.V(); v++ {
+		for _, w := range g.Adj(v) {
+			if b.Color(v) == b.Color(w) {
+				t.Errorf("%d and %d are adjacent but have the same color", v, w)
+			}
+		}
+	}
+
+	sides := [][]int{b.Side(0), b.Side(1)}
+	if len(sides[0])+len(sides[1]) != g.V() {
+		t.Errorf("Sides %v should hold every vertex", sides)
+	}
+	for c, side := range sides {
+		for _, v := range side {
+			if b.Color(v) != c {
+				t.Errorf("%d is on side %d but has color %d", v, c, b.Color(v))
+			}
+		}
+	}
+}
+
+func TestBipartitionHasOddCycle(t *testing.T) {
+	// 0-1-2-3-4-0 is an odd cycle, hanging off 5-6-0
+	g := NewGraph(7)
+	g.AddEdge(5, 6)
+	g.AddEdge(6, 0)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 0)
+
+	b := NewBipartition(g)
+	if b.IsBipartite() {
+		t.Fatalf("Graph shouldn't be bipartite")
+	}
+
+	cycle := b.OddCycle()
+	if len(cycle) != 6 {
+		t.Fatalf("Expected a cycle of 5 edges, got %v", cycle)
+	}
+	checkIsCycle(t, g, cycle)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Asking for a side should have panicked")
+		}
+	}()
+	b.Side(0)
+}
+
+func TestBipartitionSelfLoopIsOddCycle(t *testing.T) {
+	g := NewGraph(2)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 1)
+
+	cycle := NewBipartition(g).OddCycle()
+	if len(cycle) != 2 || cycle[0] != 1 || cycle[1] != 1 {
+		t.Errorf("Expected self-loop [1 1] as odd cycle, got %v", cycle)
+	}
+}
+
+// crossEdgeDigraph has an edge 2->1 between two branches of a depth-first
+// search from 0, where there is no tree path between both ends.
+func crossEdgeDigraph() Digraph {
+	di := NewDigraph(3)
+	di.AddEdge(0, 1)
+	di.AddEdge(0, 2)
+	di.AddEdge(2, 1)
+	return di
+}
+
+func TestBipartitionOfCSRGraph(t *testing.T) {
+	// a triangle hanging off 3
+	g := NewGraph(4)
+	g.AddEdge(3, 0)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 0)
+
+	c := BuildCSR(g)
+	cycle := NewBipartition(c).OddCycle()
+	if len(cycle) != 4 {
+		t.Fatalf("Expected an odd cycle of 3 edges, got %v", cycle)
+	}
+	checkIsCycle(t, c, cycle)
+}
+
+func TestIsBipartiteOfDigraph(t *testing.T) {
+	di := crossEdgeDigraph()
+	for _, g := range []Graph{di, BuildCSR(di)} {
+		if IsBipartite(g) {
+			t.Errorf("1 and 2 have the same color but are adjacent, %#v", g)
+		}
+	}
+}
+
+// checkIsCycle verifies that cycle closes on itself and follows edges of g.
+func checkIsCycle(t *testing.T, g Graph, cycle []int) {
+	if cycle[0] != cycle[len(cycle)-1] {
+		t.Errorf("Cycle should start and end on the same vertex, got %v", cycle)
+	}
+	for i := 1; i < len(cycle); i++ {
+		found := false
+		for _, w := range g.Adj(cycle[i-1]) {
+			found = found || w == cycle[i]
+		}
+		if !found {
+			t.Errorf("No edge %d-%d in cycle %v", cycle[i-1], cycle[i], cycle)
+		}
+	}
+}
+
 func TestGraphHasCycle(t *testing.T) {
 	edgeList := graphs[cycleUngraph]
 	g := NewGraph(len(edgeList) + 1)
@@ -93,6 +222,91 @@ func TestGraphHasNoCycle(t *testing.T) {
 	}
 }
 
+func TestUndirectedCycle(t *testing.T) {
+	edgeList := graphs[cycleUngraph]
+	g := NewGraph(len(edgeList) + 1)
+
+	for _, edge := range edgeList {
+		g.AddEdge(edge.from, edge.to)
+	}
+
+	cycle := UndirectedCycle(g)
+	if len(cycle) != 4 {
+		t.Fatalf("Expected a cycle of 3 edges, got %v", cycle)
+	}
+	checkIsCycle(t, g, cycle)
+}
+
+func TestUndirectedCycleOfParallelEdges(t *testing.T) {
+	// 0-1 is a tree, but 1=2 are joined by two edges
+	g := NewGraph(3)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 1)
+
+	cycle := UndirectedCycle(g)
+	if len(cycle) != 3 {
+		t.Fatalf("Expected a cycle of 2 edges, got %v", cycle)
+	}
+	checkIsCycle(t, g, cycle)
+	if !HasCycle(g) {
+		t.Errorf("Parallel edges should be a cycle")
+	}
+}
+
+func TestUndirectedCycleOfSelfLoop(t *testing.T) {
+	for _, v := range []int{0, 1} {
+		g := NewGraph(2)
+		g.AddEdge(0, 1)
+		g.AddEdge(v, v)
+
+		cycle := UndirectedCycle(g)
+		if len(cycle) != 2 || cycle[0] != v || cycle[1] != v {
+			t.Errorf("Expected self-loop [%d %d] as a cycle, got %v", v, v, cycle)
+		}
+		if !HasCycle(g) {
+			t.Errorf("Self-loop on %d should be a cycle", v)
+		}
+	}
+}
+
+func TestHasCycleOfDigraph(t *testing.T) {
+	di := crossEdgeDigraph()
+	for _, g := range []Graph{di, BuildCSR(di)} {
+		if !HasCycle(g) {
+			t.Errorf("2->1 goes back to a visited vertex, %#v", g)
+		}
+	}
+}
+
+func TestUndirectedCycleOfCSRGraph(t *testing.T) {
+	g := NewGraph(4)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 1)
+
+	c := BuildCSR(g)
+	cycle := UndirectedCycle(c)
+	if len(cycle) != 4 {
+		t.Fatalf("Expected a cycle of 3 edges, got %v", cycle)
+	}
+	checkIsCycle(t, c, cycle)
+}
+
+func TestUndirectedCycleOfTree(t *testing.T) {
+	g := NewGraph(6)
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(2, 4)
+	// 5 is alone
+
+	if cycle := UndirectedCycle(g); len(cycle) != 0 {
+		t.Errorf("Tree shouldn't have a cycle, got %v", cycle)
+	}
+}
+
 func iterStep(current int) int {
 	if current == 0 {
 		return 1
@@ -180,3 +394,44 @@ func TestAvgDegreeOfListGraph(t *testing.T) {
 
 	}
 }
+
+// deepGraphSize is the vertex count of path graphs used to check that
+// traversals don't recurse once per vertex.
+const deepGraphSize = 10000000
+
+// deepUngraph is the path graph 0-1-2-...-(v-1)
+func deepUngraph(v int) Ungraph {
+	g := NewGraph(v)
+	for w := 1; w < v; w++ {
+		g.AddEdge(w-1, w)
+	}
+	return g
+}
+
+// deepDigraph is the path digraph 0->1->2->...->(v-1)
+func deepDigraph(v int) Digraph {
+	di := NewDigraph(v)
+	for w := 1; w < v; w++ {
+		di.AddEdge(w-1, w)
+	}
+	return di
+}
+
+func TestDeepUngraph(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Not running test on deep graphs")
+	}
+	g := deepUngraph(deepGraphSize)
+
+	if HasCycle(g) {
+		t.Errorf("Path graph should not have a cycle")
+	}
+	if !IsBipartite(g) {
+		t.Errorf("Path graph should be bipartite")
+	}
+
+	g.AddEdge(deepGraphSize-1, 0)
+	if !HasCycle(g) {
+		t.Errorf("Closed path graph should have a cycle")
+	}
+}
@@ -0,0 +1,80 @@
+// Package pq holds the indexed priority queue of vertices shared by the
+// shortest path and minimum spanning tree algorithms.
+package pq
+
+import (
+	"container/heap"
+	"github.com/aybabtme/graph"
+)
+
+// IndexMin is an indexed min priority queue of vertices, ordered by their
+// priority in prio. It implements heap.Interface, and index tells where a
+// vertex sits in the heap so its priority can be fixed after it decreases.
+type IndexMin[W graph.Number] struct {
+	vertices []int
+	index    []int
+	prio     []W
+}
+
+// NewIndexMin creates an empty queue of the vertices prio has priorities
+// for.  The queue reads prio as it changes.
+func NewIndexMin[W graph.Number](prio []W) *IndexMin[W] {
+	index := make([]int, len(prio))
+	for v := range index {
+		index[v] = -1
+	}
+	return &IndexMin[W]{
+		index: index,
+		prio:  prio,
+	}
+}
+
+// Contains tells if vertex v is in the queue
+func (q *IndexMin[W]) Contains(v int) bool {
+	return q.index[v] != -1
+}
+
+// Update adds vertex v to the queue, or moves it to its place if it is
+// already there and its priority decreased.
+func (q *IndexMin[W]) Update(v int) {
+	if q.Contains(v) {
+		heap.Fix(q, q.index[v])
+	} else {
+		heap.Push(q, v)
+	}
+}
+
+// PopMin removes the vertex of lowest priority from the queue and returns it
+func (q *IndexMin[W]) PopMin() int {
+	return heap.Pop(q).(int)
+}
+
+func (q IndexMin[W]) Len() int {
+	return len(q.vertices)
+}
+
+func (q IndexMin[W]) Less(v, w int) bool {
+	return q.prio[q.vertices[v]] < q.prio[q.vertices[w]]
+}
+
+func (q IndexMin[W]) Swap(v, w int) {
+	q.vertices[v], q.vertices[w] = q.vertices[w], q.vertices[v]
+	q.index[q.vertices[v]] = v
+	q.index[q.vertices[w]] = w
+}
+
+// Push is for heap.Interface, use Update instead
+func (q *IndexMin[W]) Push(x interface{}) {
+	v := x.(int)
+	q.index[v] = len(q.vertices)
+	q.vertices = append(q.vertices, v)
+}
+
+// Pop is for heap.Interface, use PopMin instead
+func (q *IndexMin[W]) Pop() interface{} {
+	n := len(q.vertices)
+	v := q.vertices[n-1]
+	q.index[v] = -1
+	q.vertices = q.vertices[0 : n-1]
+	return v
+}
@@ -0,0 +1,37 @@
+package pq
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestIndexMinPopsInOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(14))
+	prio := make([]float64, 100)
+	q := NewIndexMin(prio)
+	for v := range prio {
+		prio[v] = r.Float64()
+		q.Update(v)
+	}
+	// decrease some priorities after they were queued
+	for v := 0; v < len(prio); v += 3 {
+		prio[v] /= 2
+		q.Update(v)
+	}
+	if !q.Contains(0) || q.Len() != len(prio) {
+		t.Fatalf("Every vertex should be queued once, got %d", q.Len())
+	}
+
+	var popped []float64
+	for q.Len() != 0 {
+		v := q.PopMin()
+		if q.Contains(v) {
+			t.Errorf("%d shouldn't be queued after being popped", v)
+		}
+		popped = append(popped, prio[v])
+	}
+	if !sort.Float64sAreSorted(popped) {
+		t.Errorf("Should pop in increasing priority, got %v", popped)
+	}
+}
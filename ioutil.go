@@ -7,6 +7,63 @@ import (
 	"strconv"
 )
 
+// scanError explains why scan stopped before a value was found: either it
+// failed reading, or the input ended early, such as when the edge count in
+// the header is larger than the number of edges that follow.
+func scanError(scan *bufio.Scanner) error {
+	if err := scan.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
+
+// MaxReadVertices bounds the number of vertices of graphs read from inputs
+// that don't state it, such as edge lists and DOT, where it is inferred from
+// the largest vertex named, and of those read as a CSRGraph, which allocates
+// for every vertex up front.  Larger vertices are reported as errors instead
+// of growing the graph until memory runs out.  Raise it to read larger
+// graphs.
+var MaxReadVertices = 1 << 24
+
+// growAdj extends adjacency lists adj to n vertices, in one allocation.
+func growAdj[T any](adj [][]T, n int) [][]T {
+	if n <= len(adj) {
+		return adj
+	}
+	return append(adj, make([][]T, n-len(adj))...)
+}
+
+// stickyWriter buffers writes to w, remembering the first error it
+// encounters so that writers can check for it once, when they're done.
+type stickyWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func newStickyWriter(w io.Writer) stickyWriter {
+	return stickyWriter{w: bufio.NewWriter(w)}
+}
+
+func (s *stickyWriter) write(p []byte) {
+	if s.err == nil {
+		_, s.err = s.w.Write(p)
+	}
+}
+
+func (s *stickyWriter) printf(format string, args ...interface{}) {
+	if s.err == nil {
+		_, s.err = fmt.Fprintf(s.w, format, args...)
+	}
+}
+
+// flush writes out what is buffered, unless a write failed before.
+func (s *stickyWriter) flush() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.w.Flush()
+}
+
 type graphScanner struct {
 	*bufio.Scanner
 }
@@ -25,7 +82,7 @@ func (w *graphScanner) NextInt() (int, error) {
 
 		return strconv.Atoi(w.Text())
 	}
-	return 0, fmt.Errorf("couldn't scan")
+	return 0, scanError(w.Scanner)
 }
 
 func (w *graphScanner) NextEdge() (from int, to int, err error) {
@@ -58,7 +115,7 @@ func (w *weightGraphScanner) NextInt() (int, error) {
 
 		return strconv.Atoi(w.Text())
 	}
-	return 0, fmt.Errorf("couldn't scan")
+	return 0, scanError(w.Scanner)
 }
 
 func (w *weightGraphScanner) NextFloat() (float64, error) {
@@ -69,7 +126,7 @@ func (w *weightGraphScanner) NextFloat() (float64, error) {
 
 		return strconv.ParseFloat(w.Text(), 64)
 	}
-	return 0, fmt.Errorf("couldn't scan")
+	return 0, scanError(w.Scanner)
 }
 
 func (w *weightGraphScanner) NextEdge() (from int, to int, weight float64, err error) {
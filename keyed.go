@@ -0,0 +1,79 @@
+package graph
+
+// KeyedGraph is a weighted graph whose vertices are named by keys of type K,
+// such as account IDs, with weights of type W.
+type KeyedGraph[K comparable, W Number] struct {
+	*symbolTable[K]
+	wg WeightGraphOf[W]
+}
+
+// NewKeyedGraph creates an empty graph with vertices named by keys of type K
+// and weights of type W
+func NewKeyedGraph[K comparable, W Number]() *KeyedGraph[K, W] {
+	return &KeyedGraph[K, W]{
+		symbolTable: newSymbolTable[K](),
+		wg:          NewWeightGraphOf[W](0),
+	}
+}
+
+// AddVertex adds the vertex named key if there is none, and returns its
+// index.
+func (k *KeyedGraph[K, W]) AddVertex(key K) int {
+	v := k.add(key)
+	if v == k.wg.V() {
+		k.wg.AddVertex()
+	}
+	return v
+}
+
+// AddEdge adds an edge of weight weight between the vertices named from and
+// to, adding those vertices if needed.
+func (k *KeyedGraph[K, W]) AddEdge(from, to K, weight W) {
+	v, w := k.AddVertex(from), k.AddVertex(to)
+	k.wg.AddEdge(NewEdgeOf(v, w, weight))
+}
+
+// Graph is the underlying graph, indexed by the vertices of this keyed
+// graph.
+func (k *KeyedGraph[K, W]) Graph() *WeightGraphOf[W] {
+	return &k.wg
+}
+
+// KeyedDigraph is a weighted digraph whose vertices are named by keys of
+// type K, such as account IDs, with weights of type W.
+type KeyedDigraph[K comparable, W Number] struct {
+	*symbolTable[K]
+	wd WeightDigraphOf[W]
+}
+
+// NewKeyedDigraph creates an empty digraph with vertices named by keys of
+// type K and weights of type W
+func NewKeyedDigraph[K comparable, W Number]() *KeyedDigraph[K, W] {
+	return &KeyedDigraph[K, W]{
+		symbolTable: newSymbolTable[K](),
+		wd:          NewWeightDigraphOf[W](0),
+	}
+}
+
+// AddVertex adds the vertex named key if there is none, and returns its
+// index.
+func (k *KeyedDigraph[K, W]) AddVertex(key K) int {
+	v := k.add(key)
+	if v == k.wd.V() {
+		k.wd.AddVertex()
+	}
+	return v
+}
+
+// AddEdge adds an edge of weight weight from the vertex named from to the
+// one named to, adding those vertices if needed.
+func (k *KeyedDigraph[K, W]) AddEdge(from, to K, weight W) {
+	v, w := k.AddVertex(from), k.AddVertex(to)
+	k.wd.AddEdge(NewDirectedEdgeOf(v, w, weight))
+}
+
+// Digraph is the underlying digraph, indexed by the vertices of this keyed
+// digraph.
+func (k *KeyedDigraph[K, W]) Digraph() *WeightDigraphOf[W] {
+	return &k.wd
+}
@@ -0,0 +1,217 @@
+package matching
+
+import (
+	"fmt"
+	"github.com/aybabtme/graph"
+)
+
+type hopcroftKarp struct {
+	mate    []int
+	size    int
+	inCover []bool
+	// limit is the length of the shortest augmenting paths in the current
+	// phase
+	limit int
+}
+
+// BuildHopcroftKarp finds a maximum matching of bipartite graph g, whose
+// vertices v are on one side when color[v] is false and on the other side
+// otherwise, such as workers and the jobs they can do.  Every edge of g must
+// join both sides.  This is O(E sqrt(V)).  It returns an error if color
+// isn't a bipartition of g.
+func BuildHopcroftKarp(g graph.Ungraph, color []bool) (Matching, error) {
+	if len(color) != g.V() {
+		return nil, fmt.Errorf("bipartition has %d vertices but graph has %d",
+			len(color), g.V())
+	}
+	for v := 0; v < g.V(); v++ {
+		for _, w := range g.Adj(v) {
+			if color[v] == color[w] {
+				return nil, fmt.Errorf("edge %d-%d has both vertices on the same side", v, w)
+			}
+		}
+	}
+
+	h := &hopcroftKarp{
+		mate: make([]int, g.V()),
+	}
+	for v := range h.mate {
+		h.mate[v] = -1
+	}
+
+	dist := make([]int, g.V())
+	next := make([]int, g.V())
+	for h.buildLayers(g, color, dist) {
+		for v := range next {
+			next[v] = 0
+		}
+		for v := 0; v < g.V(); v++ {
+			if !color[v] && h.mate[v] == -1 && h.augment(g, v, dist, next) {
+				h.size++
+			}
+		}
+	}
+
+	h.buildCover(g, color)
+	return h, nil
+}
+
+// BuildBipartiteMatching is BuildHopcroftKarp with the sides of g given by
+// bipartition b of g, such as graph.NewBipartition(g).  It returns an error
+// if g isn't bipartite, or if b isn't a bipartition of g.
+func BuildBipartiteMatching(g graph.Ungraph, b graph.Bipartition) (Matching, error) {
+	if !b.IsBipartite() {
+		return nil, fmt.Errorf("graph isn't bipartite, it has odd cycle %v", b.OddCycle())
+	}
+	color := make([]bool, g.V())
+	left, right := b.Side(0), b.Side(1)
+	if len(left)+len(right) != g.V() {
+		return nil, fmt.Errorf("bipartition has %d vertices but graph has %d",
+			len(left)+len(right), g.V())
+	}
+	for _, v := range right {
+		color[v] = true
+	}
+	return BuildHopcroftKarp(g, color)
+}
+
+// unreached is the layer of vertices not reached by buildLayers
+const unreached = -1
+
+// buildLayers does a breadth-first search from every unmatched vertex on the
+// false side, alternating between unmatched and matched edges.  dist[v] is
+// the layer of v when it is on the false side, and the search tells if it
+// found an augmenting path.  Layers past the shortest augmenting paths are
+// left unreached.
+func (h *hopcroftKarp) buildLayers(g graph.Ungraph, color []bool, dist []int) bool {
+	var queue []int
+	for v := 0; v < g.V(); v++ {
+		dist[v] = unreached
+		if !color[v] && h.mate[v] == -1 {
+			dist[v] = 0
+			queue = append(queue, v)
+		}
+	}
+
+	h.limit = unreached
+	for len(queue) != 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if h.limit != unreached && dist[v] >= h.limit {
+			continue
+		}
+		for _, w := range g.Adj(v) {
+			u := h.mate[w]
+			if u == -1 {
+				if h.limit == unreached {
+					h.limit = dist[v] + 1
+				}
+			} else if dist[u] == unreached {
+				dist[u] = dist[v] + 1
+				queue = append(queue, u)
+			}
+		}
+	}
+	return h.limit != unreached
+}
+
+// augment does a depth-first search from s along the layers, flipping the
+// edges of the first augmenting path it finds.  Vertices on the false side
+// found to be dead ends are removed from the layers.
+func (h *hopcroftKarp) augment(g graph.Ungraph, s int, dist, next []int) bool {
+	stack := []int{s}
+	for len(stack) != 0 {
+		v := stack[len(stack)-1]
+		adj := g.Adj(v)
+
+		if next[v] == len(adj) {
+			dist[v] = unreached
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		w := adj[next[v]]
+		next[v]++
+
+		u := h.mate[w]
+		if u != -1 {
+			if dist[u] == dist[v]+1 {
+				stack = append(stack, u)
+			}
+			continue
+		}
+		if dist[v]+1 != h.limit {
+			continue
+		}
+
+		// w is free: flip every edge on the path from s to w, each vertex
+		// on the stack taking the vertex it went through as its mate
+		for i := len(stack) - 1; i >= 0; i-- {
+			v := stack[i]
+			previous := h.mate[v]
+			h.mate[v] = w
+			h.mate[w] = v
+			w = previous
+		}
+		return true
+	}
+	return false
+}
+
+// buildCover finds a minimum vertex cover with König's theorem: marking
+// every vertex reachable from an unmatched vertex on the false side by
+// alternating paths, the cover is made of the unmarked vertices on the false
+// side and the marked vertices on the true side.
+func (h *hopcroftKarp) buildCover(g graph.Ungraph, color []bool) {
+	marked := make([]bool, g.V())
+	var queue []int
+	for v := 0; v < g.V(); v++ {
+		if !color[v] && h.mate[v] == -1 {
+			marked[v] = true
+			queue = append(queue, v)
+		}
+	}
+
+	for len(queue) != 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range g.Adj(v) {
+			// unmatched edges from the false side, matched edges back
+			if marked[w] || (h.mate[v] == w) == !color[v] {
+				continue
+			}
+			marked[w] = true
+			queue = append(queue, w)
+		}
+	}
+
+	h.inCover = make([]bool, g.V())
+	for v := range h.inCover {
+		h.inCover[v] = marked[v] == color[v]
+	}
+}
+
+func (h *hopcroftKarp) Mate(v int) int {
+	return h.mate[v]
+}
+
+func (h *hopcroftKarp) IsMatched(v int) bool {
+	return h.mate[v] != -1
+}
+
+func (h *hopcroftKarp) Size() int {
+	return h.size
+}
+
+func (h *hopcroftKarp) InMinVertexCover(v int) bool {
+	return h.inCover[v]
+}
+
+func (h *hopcroftKarp) MinVertexCover() []int {
+	var cover []int
+	for v, in := range h.inCover {
+		if in {
+			cover = append(cover, v)
+		}
+	}
+	return cover
+}
@@ -0,0 +1,185 @@
+package matching
+
+import (
+	"github.com/aybabtme/graph"
+	"github.com/aybabtme/graph/flow"
+	"math/rand"
+	"testing"
+)
+
+func TestHopcroftKarpMatchesKnownOutput(t *testing.T) {
+	// Workers 0, 1, 2 and 3 can work on shards 4, 5, 6 and 7
+	//
+	//   0 --- 4
+	//   1 -+- 5
+	//   2 -+  6
+	//   3 --- 4
+	//
+	// Only one of 0 and 3 can get shard 4, and 1 and 2 compete for 5.
+	g := graph.NewGraph(8)
+	g.AddEdge(0, 4)
+	g.AddEdge(1, 5)
+	g.AddEdge(2, 5)
+	g.AddEdge(3, 4)
+	color := []bool{false, false, false, false, true, true, true, true}
+
+	m, err := BuildHopcroftKarp(g, color)
+	if err != nil {
+		t.Fatalf("Couldn't build matching, %v", err)
+	}
+
+	if m.Size() != 2 {
+		t.Errorf("Expected matching of size 2, was %d", m.Size())
+	}
+	if m.IsMatched(6) || m.Mate(6) != -1 {
+		t.Errorf("Shard 6 has no worker, but was matched with %d", m.Mate(6))
+	}
+	checkMatching(t, g, m)
+}
+
+func TestHopcroftKarpNeedsAugmentingPaths(t *testing.T) {
+	// A path 0-5-1-6-2-7-3-8-4 where a greedy matching of 5-1, 6-2, 7-3
+	// must be undone to match everyone on the smaller side.
+	g := graph.NewGraph(9)
+	color := make([]bool, 9)
+	for v := 5; v < 9; v++ {
+		color[v] = true
+	}
+	g.AddEdge(5, 1)
+	g.AddEdge(6, 2)
+	g.AddEdge(7, 3)
+	g.AddEdge(0, 5)
+	g.AddEdge(1, 6)
+	g.AddEdge(2, 7)
+	g.AddEdge(3, 8)
+	g.AddEdge(4, 8)
+
+	m, err := BuildHopcroftKarp(g, color)
+	if err != nil {
+		t.Fatalf("Couldn't build matching, %v", err)
+	}
+	if m.Size() != 4 {
+		t.Errorf("Expected matching of size 4, was %d", m.Size())
+	}
+	checkMatching(t, g, m)
+}
+
+func TestHopcroftKarpMatchesMaxFlow(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 50; i++ {
+		left, right := 1+r.Intn(30), 1+r.Intn(30)
+		g := graph.NewGraph(left + right)
+		color := make([]bool, left+right)
+		for v := left; v < left+right; v++ {
+			color[v] = true
+		}
+
+		// source is left+right, sink is left+right+1
+		fn := flow.NewFlowNetwork(left + right + 2)
+		s, sink := left+right, left+right+1
+		for v := 0; v < left; v++ {
+			fn.AddEdge(flow.NewFlowEdge(s, v, 1))
+		}
+		for w := left; w < left+right; w++ {
+			fn.AddEdge(flow.NewFlowEdge(w, sink, 1))
+		}
+		for e := r.Intn(3 * (left + right)); e > 0; e-- {
+			v, w := r.Intn(left), left+r.Intn(right)
+			g.AddEdge(v, w)
+			fn.AddEdge(flow.NewFlowEdge(v, w, 1))
+		}
+
+		m, err := BuildHopcroftKarp(g, color)
+		if err != nil {
+			t.Fatalf("Couldn't build matching, %v", err)
+		}
+		mf, err := flow.BuildDinic(&fn, s, sink)
+		if err != nil {
+			t.Fatalf("Couldn't build max flow, %v", err)
+		}
+
+		if float64(m.Size()) != mf.Value() {
+			t.Fatalf("Matching has size %d but max flow is %f", m.Size(), mf.Value())
+		}
+		checkMatching(t, g, m)
+	}
+}
+
+func TestHopcroftKarpRefusesBadBipartition(t *testing.T) {
+	g := graph.NewGraph(3)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+
+	if _, err := BuildHopcroftKarp(g, []bool{false, true}); err == nil {
+		t.Errorf("Should refuse a bipartition of the wrong size")
+	}
+	if _, err := BuildHopcroftKarp(g, []bool{false, true, true}); err == nil {
+		t.Errorf("Should refuse an edge within one side")
+	}
+}
+
+func TestBipartiteMatching(t *testing.T) {
+	// an even cycle 0-1-2-3-0 with a tail 3-4
+	g := graph.NewGraph(5)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 0)
+	g.AddEdge(3, 4)
+
+	m, err := BuildBipartiteMatching(g, graph.NewBipartition(g))
+	if err != nil {
+		t.Fatalf("Couldn't build matching, %v", err)
+	}
+	if m.Size() != 2 {
+		t.Errorf("Expected matching of size 2, was %d", m.Size())
+	}
+	checkMatching(t, g, m)
+
+	if _, err := BuildBipartiteMatching(g, graph.NewBipartition(graph.NewGraph(2))); err == nil {
+		t.Errorf("Should refuse a bipartition of another graph")
+	}
+	g.AddEdge(0, 2)
+	if _, err := BuildBipartiteMatching(g, graph.NewBipartition(g)); err == nil {
+		t.Errorf("Should refuse a graph with an odd cycle")
+	}
+}
+
+// checkMatching verifies that m is a matching of g, and that its vertex
+// cover covers every edge of g with as many vertices as m has edges.
+func checkMatching(t *testing.T, g graph.Ungraph, m Matching) {
+	matched := 0
+	for v := 0; v < g.V(); v++ {
+		w := m.Mate(v)
+		if w == -1 {
+			continue
+		}
+		matched++
+		if m.Mate(w) != v {
+			t.Errorf("%d is matched with %d, but %d is matched with %d",
+				v, w, w, m.Mate(w))
+		}
+		found := false
+		for _, adj := range g.Adj(v) {
+			found = found || adj == w
+		}
+		if !found {
+			t.Errorf("%d is matched with %d, but they're not adjacent", v, w)
+		}
+	}
+	if matched != 2*m.Size() {
+		t.Errorf("%d vertices are matched, but size is %d", matched, m.Size())
+	}
+
+	cover := m.MinVertexCover()
+	if len(cover) != m.Size() {
+		t.Errorf("Vertex cover %v should have %d vertices", cover, m.Size())
+	}
+	for v := 0; v < g.V(); v++ {
+		for _, w := range g.Adj(v) {
+			if !m.InMinVertexCover(v) && !m.InMinVertexCover(w) {
+				t.Errorf("Edge %d-%d isn't covered by %v", v, w, cover)
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Package matching implements algorithms finding maximum matchings in
+// graphs.
+package matching
+
+// Matching is a maximum cardinality matching in a bipartite graph, along
+// with a minimum vertex cover that certifies it is maximum.
+type Matching interface {
+	// Mate is the vertex matched with v, or -1 if v is unmatched
+	Mate(v int) int
+	// IsMatched tells if v is matched with another vertex
+	IsMatched(v int) bool
+	// Size is the number of edges in the matching
+	Size() int
+	// InMinVertexCover tells if v is in the minimum vertex cover
+	InMinVertexCover(v int) bool
+	// MinVertexCover is the minimum vertex cover, in increasing order.  By
+	// König's theorem, it has as many vertices as the matching has edges.
+	MinVertexCover() []int
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package graph
+
+import (
+	"os"
+)
+
+// mapFile reads the file at path in memory, where it can't be mapped.
+func mapFile(path string) ([]byte, func([]byte) error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func([]byte) error { return nil }, nil
+}
@@ -0,0 +1,201 @@
+package mst
+
+import (
+	"fmt"
+	"github.com/aybabtme/graph"
+	"github.com/aybabtme/graph/unionfind"
+)
+
+// Arborescence is a minimum spanning arborescence of a weighted digraph: a
+// tree of minimum weight whose edges point away from its root and reach
+// every vertex.
+type Arborescence interface {
+	// Edges in the arborescence, one into every vertex but the root, in
+	// increasing order of the vertex they go to
+	Edges() []graph.DirectedEdge
+	// Weight gives the total weight of the arborescence
+	Weight() float64
+}
+
+type arborescence struct {
+	tree   []graph.DirectedEdge
+	weight float64
+}
+
+// BuildArborescence builds the minimum spanning arborescence of weighted
+// digraph wd rooted at root, using Tarjan's version of the Chu-Liu/Edmonds
+// algorithm.  Every vertex keeps its incoming edges in a meldable heap, and
+// cycles of cheapest incoming edges are contracted by merging their heaps.
+// This is O(E log V) and extra space proportional to E.  It returns an error
+// if a vertex can't be reached from root.
+func BuildArborescence(wd *graph.EdgeWeightedDigraph, root int) (Arborescence, error) {
+	n := wd.V()
+	edges := wd.Edges()
+
+	heaps := make([]*inHeap, n)
+	for i, e := range edges {
+		if e.From() != e.To() {
+			heaps[e.To()] = heaps[e.To()].merge(&inHeap{weight: e.Weight(), edge: i})
+		}
+	}
+
+	uf := unionfind.BuildRollbackUF(n)
+	seen := make([]int, n)
+	for v := range seen {
+		seen[v] = -1
+	}
+	seen[root] = root
+
+	// in[v] is the edge chosen to enter v, or the contracted vertex v
+	in := make([]int, n)
+	queue := make([]int, n)
+	path := make([]int, n)
+
+	type cycle struct {
+		u        int
+		snapshot int
+		edges    []int
+	}
+	var cycles []cycle
+
+	for s := 0; s < n; s++ {
+		u, qi := s, 0
+		for seen[u] < 0 {
+			if heaps[u] == nil {
+				return nil, fmt.Errorf("vertex %d is unreachable from root %d", u, root)
+			}
+			// take the cheapest edge into u, and make it free for the
+			// other edges into u
+			weight, edge := heaps[u].top()
+			heaps[u].delta -= weight
+			heaps[u] = heaps[u].pop()
+
+			queue[qi], path[qi] = edge, u
+			qi++
+			seen[u] = s
+			u = uf.Find(edges[edge].From())
+
+			if seen[u] == s {
+				// the cheapest edges into the vertices on the path form a
+				// cycle, contract it into a single vertex
+				var contracted *inHeap
+				end, snapshot := qi, uf.Snapshot()
+				for {
+					qi--
+					w := path[qi]
+					contracted = contracted.merge(heaps[w])
+					if uf.Connected(u, w) {
+						break
+					}
+					uf.Union(u, w)
+				}
+				u = uf.Find(u)
+				heaps[u] = contracted
+				seen[u] = -1
+				cycles = append(cycles, cycle{
+					u:        u,
+					snapshot: snapshot,
+					edges:    append([]int(nil), queue[qi:end]...),
+				})
+			}
+		}
+		for i := 0; i < qi; i++ {
+			in[uf.Find(edges[queue[i]].To())] = queue[i]
+		}
+	}
+
+	// Expand the cycles, latest first: every edge of a cycle enters its
+	// vertex, except the one where the edge entering the cycle arrives.
+	for i := len(cycles) - 1; i >= 0; i-- {
+		c := cycles[i]
+		uf.Rollback(c.snapshot)
+		inEdge := in[c.u]
+		for _, e := range c.edges {
+			in[uf.Find(edges[e].To())] = e
+		}
+		in[uf.Find(edges[inEdge].To())] = inEdge
+	}
+
+	var a arborescence
+	for v := 0; v < n; v++ {
+		if v == root {
+			continue
+		}
+		e := edges[in[v]]
+		a.tree = append(a.tree, e)
+		a.weight += e.Weight()
+	}
+	return a, nil
+}
+
+func (a arborescence) Edges() []graph.DirectedEdge {
+	return a.tree
+}
+
+func (a arborescence) Weight() float64 {
+	return a.weight
+}
+
+// inHeap is a leftist heap of the edges going into a vertex, ordered by
+// weight.  delta is lazily added to the weight of every edge in the heap.
+type inHeap struct {
+	weight      float64
+	edge        int
+	delta       float64
+	rank        int
+	left, right *inHeap
+}
+
+func (h *inHeap) push() {
+	if h.delta == 0 {
+		return
+	}
+	h.weight += h.delta
+	if h.left != nil {
+		h.left.delta += h.delta
+	}
+	if h.right != nil {
+		h.right.delta += h.delta
+	}
+	h.delta = 0
+}
+
+// top gives the weight and index of the cheapest edge in the heap.
+func (h *inHeap) top() (float64, int) {
+	h.push()
+	return h.weight, h.edge
+}
+
+func (h *inHeap) pop() *inHeap {
+	h.push()
+	return h.left.merge(h.right)
+}
+
+func (h *inHeap) getRank() int {
+	if h == nil {
+		return 0
+	}
+	return h.rank
+}
+
+// merge melds heaps h and other.  It recurses along right spines, which are
+// at most O(log n) long in a leftist heap.
+func (h *inHeap) merge(other *inHeap) *inHeap {
+	if h == nil {
+		return other
+	}
+	if other == nil {
+		return h
+	}
+	h.push()
+	other.push()
+	if other.weight < h.weight {
+		h, other = other, h
+	}
+	h.right = h.right.merge(other)
+	if h.left.getRank() < h.right.getRank() {
+		h.left, h.right = h.right, h.left
+	}
+	h.rank = h.right.getRank() + 1
+	return h
+}
@@ -0,0 +1,152 @@
+package mst
+
+import (
+	. "github.com/aybabtme/graph"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestArborescenceMatchesKnownOutput(t *testing.T) {
+	// The cheapest edges into 1 and 2 form the cycle 1->2->1, which must be
+	// broken by the cheaper of 0->1 and 0->2 once their weights are reduced
+	// by the cycle edges.
+	wd := NewEdgeWeightedDigraph(4)
+	wd.AddEdge(NewDirectedEdge(0, 1, 10))
+	wd.AddEdge(NewDirectedEdge(0, 2, 12))
+	wd.AddEdge(NewDirectedEdge(1, 2, 1))
+	wd.AddEdge(NewDirectedEdge(2, 1, 2))
+	wd.AddEdge(NewDirectedEdge(2, 3, 4))
+	wd.AddEdge(NewDirectedEdge(1, 3, 8))
+	wd.AddEdge(NewDirectedEdge(3, 3, 0))
+
+	a, err := BuildArborescence(&wd, 0)
+	if err != nil {
+		t.Fatalf("Should have an arborescence, %v", err)
+	}
+
+	expected := []DirectedEdge{
+		NewDirectedEdge(0, 1, 10),
+		NewDirectedEdge(1, 2, 1),
+		NewDirectedEdge(2, 3, 4),
+	}
+	if a.Weight() != 15 {
+		t.Errorf("Expected weight 15 but was %f", a.Weight())
+	}
+	edges := a.Edges()
+	if len(edges) != len(expected) {
+		t.Fatalf("Expected %d edges but was %d", len(expected), len(edges))
+	}
+	for i := range expected {
+		if expected[i] != edges[i] {
+			t.Errorf("Expected edge %#v but was %#v", &expected[i], &edges[i])
+		}
+	}
+}
+
+func TestArborescenceUnreachable(t *testing.T) {
+	wd := NewEdgeWeightedDigraph(3)
+	wd.AddEdge(NewDirectedEdge(0, 1, 1))
+	wd.AddEdge(NewDirectedEdge(2, 1, 1))
+
+	if _, err := BuildArborescence(&wd, 0); err == nil {
+		t.Errorf("Should fail since 2 is unreachable from 0")
+	}
+}
+
+func TestArborescenceMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 200; i++ {
+		v := 1 + r.Intn(6)
+		wd := NewEdgeWeightedDigraph(v)
+		for e := r.Intn(3 * v); e > 0; e-- {
+			wd.AddEdge(NewDirectedEdge(r.Intn(v), r.Intn(v), float64(r.Intn(20)-5)))
+		}
+		root := r.Intn(v)
+
+		want, ok := bruteForceArborescence(&wd, root)
+		a, err := BuildArborescence(&wd, root)
+		if !ok {
+			if err == nil {
+				t.Fatalf("Should have no arborescence from %d in\n%s", root, wd.GoString())
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Should have an arborescence from %d, %v, in\n%s", root, err, wd.GoString())
+		}
+		if math.Abs(a.Weight()-want) > 1e-9 {
+			t.Fatalf("Expected weight %f but was %f from %d in\n%s",
+				want, a.Weight(), root, wd.GoString())
+		}
+		checkArborescence(t, v, root, a)
+	}
+}
+
+// checkArborescence verifies that every vertex but root has one edge into
+// it, and follows those edges back to root.
+func checkArborescence(t *testing.T, v, root int, a Arborescence) {
+	parent := make([]int, v)
+	for i := range parent {
+		parent[i] = -1
+	}
+	weight := 0.0
+	for _, e := range a.Edges() {
+		if e.To() == root || parent[e.To()] != -1 {
+			t.Fatalf("Vertex %d has more than one edge into it", e.To())
+		}
+		parent[e.To()] = e.From()
+		weight += e.Weight()
+	}
+	if math.Abs(weight-a.Weight()) > 1e-9 {
+		t.Errorf("Edges weigh %f but weight is %f", weight, a.Weight())
+	}
+	for w := 0; w < v; w++ {
+		x := w
+		for steps := 0; x != root; steps++ {
+			if x == -1 || steps > v {
+				t.Fatalf("Vertex %d doesn't lead back to root %d", w, root)
+			}
+			x = parent[x]
+		}
+	}
+}
+
+// bruteForceArborescence tries every choice of one edge into each vertex.
+func bruteForceArborescence(wd *EdgeWeightedDigraph, root int) (float64, bool) {
+	into := make([][]DirectedEdge, wd.V())
+	for _, e := range wd.Edges() {
+		into[e.To()] = append(into[e.To()], e)
+	}
+
+	best, found := math.Inf(1), false
+	parent := make([]int, wd.V())
+	var try func(v int, weight float64)
+	try = func(v int, weight float64) {
+		if v == wd.V() {
+			for w := range parent {
+				x := w
+				for steps := 0; x != root; steps++ {
+					if steps > wd.V() {
+						return
+					}
+					x = parent[x]
+				}
+			}
+			if weight < best {
+				best, found = weight, true
+			}
+			return
+		}
+		if v == root {
+			try(v+1, weight)
+			return
+		}
+		for _, e := range into[v] {
+			parent[v] = e.From()
+			try(v+1, weight+e.Weight())
+		}
+	}
+	try(0, 0)
+	return best, found
+}
@@ -66,6 +66,20 @@ func Benchmark_Prime_TinyEWG(b *testing.B)   { benchmarkGraph(BuildLazyPrimMST,
 func Benchmark_Prime_MediumEWG(b *testing.B) { benchmarkGraph(BuildLazyPrimMST, medium, b) }
 func Benchmark_Prime_LargeEWG(b *testing.B)  { benchmarkGraph(BuildLazyPrimMST, large, b) }
 
+func Benchmark_EagerPrim_TinyEWG(b *testing.B)   { benchmarkGraph(BuildEagerPrimMST, tiny, b) }
+func Benchmark_EagerPrim_MediumEWG(b *testing.B) { benchmarkGraph(BuildEagerPrimMST, medium, b) }
+func Benchmark_EagerPrim_LargeEWG(b *testing.B)  { benchmarkGraph(BuildEagerPrimMST, large, b) }
+
+func Benchmark_Boruvka_TinyEWG(b *testing.B)   { benchmarkGraph(BuildBoruvkaMST, tiny, b) }
+func Benchmark_Boruvka_MediumEWG(b *testing.B) { benchmarkGraph(BuildBoruvkaMST, medium, b) }
+func Benchmark_Boruvka_LargeEWG(b *testing.B)  { benchmarkGraph(BuildBoruvkaMST, large, b) }
+
+func Benchmark_ParBoruvka_TinyEWG(b *testing.B)   { benchmarkGraph(parallelBoruvka, tiny, b) }
+func Benchmark_ParBoruvka_MediumEWG(b *testing.B) { benchmarkGraph(parallelBoruvka, medium, b) }
+func Benchmark_ParBoruvka_LargeEWG(b *testing.B)  { benchmarkGraph(parallelBoruvka, large, b) }
+
+func parallelBoruvka(wg *graph.WeightGraph) MST { return BuildParallelBoruvkaMST(wg, 0) }
+
 func benchmarkGraph(mstB mstBuilder, graphLoader lazyWeightGraph, b *testing.B) {
 	b.ResetTimer()
 	b.StartTimer()
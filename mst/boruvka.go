@@ -0,0 +1,142 @@
+package mst
+
+import (
+	"github.com/aybabtme/graph"
+	"github.com/aybabtme/graph/unionfind"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+type boruvka struct {
+	tree   []graph.Edge
+	weight float64
+}
+
+// BuildBoruvkaMST builds the minimum spanning forest for a weighted graph wg
+// using Boruvka's algorithm, which adds the cheapest edge leaving every
+// component at each of at most log V phases.  This is O(E log V) and extra
+// space proportional to V.
+func BuildBoruvkaMST(wg *graph.WeightGraph) MST {
+	return buildBoruvka(wg, 1)
+}
+
+// BuildParallelBoruvkaMST is BuildBoruvkaMST, with every phase spread across
+// workers goroutines: each offers a share of the edges as the cheapest
+// leaving their components, then adds the cheapest edges of a share of the
+// components to a shared unionfind.ConcurrentUF.  If workers is less than 1,
+// it uses GOMAXPROCS goroutines.  The workers share their extra space, which
+// stays proportional to V whatever their number.
+func BuildParallelBoruvkaMST(wg *graph.WeightGraph, workers int) MST {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return buildBoruvka(wg, workers)
+}
+
+func buildBoruvka(wg *graph.WeightGraph, workers int) MST {
+	var b boruvka
+
+	edges := wg.Edges()
+	uf := unionfind.BuildConcurrentUF(wg.V())
+
+	// Ties are broken by edge index, so that all the components agree on
+	// which of two equal edges is cheaper and never close a cycle.
+	cheaper := func(e, f int) bool {
+		if f == -1 {
+			return true
+		}
+		we, wf := edges[e].Weight(), edges[f].Weight()
+		return we < wf || (we == wf && e < f)
+	}
+
+	comp := make([]int, wg.V())
+	// cheapest[c] is the index of the cheapest edge leaving component c
+	// found so far, or -1
+	cheapest := make([]atomic.Int64, wg.V())
+	added := make([]bool, wg.V())
+
+	// offer makes edge i the cheapest leaving component c, unless a cheaper
+	// one was found.  Since cheaper is a total order, the same edge wins
+	// whatever the order of the offers.
+	offer := func(c, i int) {
+		for {
+			old := cheapest[c].Load()
+			if !cheaper(i, int(old)) || cheapest[c].CompareAndSwap(old, int64(i)) {
+				return
+			}
+		}
+	}
+
+	// parallel runs work on workers goroutines, each given a share of the
+	// n items as the range [from, to).  With a single worker, it runs work
+	// right away.
+	parallel := func(n int, work func(from, to int)) {
+		if workers == 1 {
+			work(0, n)
+			return
+		}
+		var done sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			done.Add(1)
+			go func(i int) {
+				defer done.Done()
+				work(i*n/workers, (i+1)*n/workers)
+			}(i)
+		}
+		done.Wait()
+	}
+
+	for {
+		parallel(len(comp), func(from, to int) {
+			for v := from; v < to; v++ {
+				comp[v] = uf.Find(v)
+				cheapest[v].Store(-1)
+			}
+		})
+
+		parallel(len(edges), func(from, to int) {
+			for i := from; i < to; i++ {
+				v := edges[i].Either()
+				cv, cw := comp[v], comp[edges[i].Other(v)]
+				if cv != cw {
+					offer(cv, i)
+					offer(cw, i)
+				}
+			}
+		})
+
+		parallel(len(comp), func(from, to int) {
+			for c := from; c < to; c++ {
+				// the cheapest edge of a component can also be the
+				// cheapest of the other, only one of them adds it
+				added[c] = false
+				if i := int(cheapest[c].Load()); i != -1 {
+					v := edges[i].Either()
+					added[c] = uf.Merge(v, edges[i].Other(v))
+				}
+			}
+		})
+
+		progress := false
+		for c := range added {
+			if added[c] {
+				i := cheapest[c].Load()
+				b.tree = append(b.tree, edges[i])
+				b.weight += edges[i].Weight()
+				progress = true
+			}
+		}
+		if !progress {
+			return b
+		}
+	}
+}
+
+func (b boruvka) Edges() []graph.Edge {
+	return b.tree
+}
+
+func (b boruvka) Weight() float64 {
+	return b.weight
+}
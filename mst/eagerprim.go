@@ -0,0 +1,66 @@
+package mst
+
+import (
+	"github.com/aybabtme/graph"
+	"github.com/aybabtme/graph/internal/pq"
+	"math"
+)
+
+type eagerPrim struct {
+	tree   []graph.Edge
+	weight float64
+}
+
+// BuildEagerPrimMST builds the minimum spanning forest for a weighted graph
+// wg, growing a tree from every vertex not yet in the forest.  Unlike the
+// lazy version, it only keeps the cheapest edge from the tree to each
+// vertex.  This is O(E log V) and extra space proportional to V.
+func BuildEagerPrimMST(wg *graph.WeightGraph) MST {
+	var p eagerPrim
+
+	edgeTo := make([]graph.Edge, wg.V())
+	distTo := make([]float64, wg.V())
+	marked := make([]bool, wg.V())
+	for v := range distTo {
+		distTo[v] = math.Inf(1)
+	}
+
+	queue := pq.NewIndexMin(distTo)
+
+	for s := 0; s < wg.V(); s++ {
+		if marked[s] {
+			continue
+		}
+		distTo[s] = 0.0
+		queue.Update(s)
+
+		for queue.Len() != 0 {
+			v := queue.PopMin()
+			marked[v] = true
+			if v != s {
+				p.tree = append(p.tree, edgeTo[v])
+				p.weight += edgeTo[v].Weight()
+			}
+
+			for _, e := range wg.Adj(v) {
+				w := e.Other(v)
+				if marked[w] || e.Weight() >= distTo[w] {
+					continue
+				}
+				edgeTo[w] = e
+				distTo[w] = e.Weight()
+				queue.Update(w)
+			}
+		}
+	}
+
+	return p
+}
+
+func (p eagerPrim) Edges() []graph.Edge {
+	return p.tree
+}
+
+func (p eagerPrim) Weight() float64 {
+	return p.weight
+}
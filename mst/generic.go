@@ -0,0 +1,58 @@
+package mst
+
+import (
+	"github.com/aybabtme/graph"
+	"github.com/aybabtme/graph/unionfind"
+	"sort"
+)
+
+// MSTOf is MST for a graph.WeightGraphOf, whose weight has the type of the
+// weights of the graph.  With integer weights, it is exact.
+type MSTOf[W graph.Number] interface {
+	// Edges in the MST
+	Edges() []graph.EdgeOf[W]
+	// Weight gives the total weight of the MST
+	Weight() W
+}
+
+type kruskalOf[W graph.Number] struct {
+	tree   []graph.EdgeOf[W]
+	weight W
+}
+
+// BuildKruskalMSTOf is BuildKruskalMST for a weighted graph wg with weights
+// of any type, building its minimum spanning forest.  Other algorithms can
+// run on wg.Float64(), but their weights are then rounded like float64s.
+// This is O(E log E).
+func BuildKruskalMSTOf[W graph.Number](wg *graph.WeightGraphOf[W]) MSTOf[W] {
+	var k kruskalOf[W]
+
+	edges := wg.Edges()
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Less(edges[j])
+	})
+
+	uf := unionfind.BuildUF(wg.V())
+	for _, e := range edges {
+		if len(k.tree) == wg.V()-1 {
+			break
+		}
+		v := e.Either()
+		w := e.Other(v)
+		if !uf.Connected(v, w) {
+			uf.Union(v, w)
+			k.tree = append(k.tree, e)
+			k.weight += e.Weight()
+		}
+	}
+
+	return k
+}
+
+func (k kruskalOf[W]) Edges() []graph.EdgeOf[W] {
+	return k.tree
+}
+
+func (k kruskalOf[W]) Weight() W {
+	return k.weight
+}
@@ -0,0 +1,48 @@
+package mst
+
+import (
+	. "github.com/aybabtme/graph"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestKruskalOfIsExact(t *testing.T) {
+	// 2^53 + 1 can't be represented by a float64
+	big := int64(1)<<53 + 1
+
+	wg := NewWeightGraphOf[int64](4)
+	wg.AddEdge(NewEdgeOf(0, 1, big))
+	wg.AddEdge(NewEdgeOf(1, 2, big))
+	wg.AddEdge(NewEdgeOf(2, 3, big))
+	wg.AddEdge(NewEdgeOf(3, 0, big+1))
+
+	mst := BuildKruskalMSTOf(&wg)
+	if mst.Weight() != 3*big {
+		t.Errorf("Expected weight %d but was %d", 3*big, mst.Weight())
+	}
+	if len(mst.Edges()) != 3 {
+		t.Errorf("Expected 3 edges but was %d", len(mst.Edges()))
+	}
+}
+
+func TestKruskalOfMatchesKruskal(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+	for i := 0; i < 50; i++ {
+		v := 1 + r.Intn(100)
+		wg := NewWeightGraphOf[uint16](v)
+		for e := r.Intn(4 * v); e > 0; e-- {
+			wg.AddEdge(NewEdgeOf(r.Intn(v), r.Intn(v), uint16(r.Intn(1000))))
+		}
+
+		f := wg.Float64()
+		want := BuildKruskalMST(&f)
+		got := BuildKruskalMSTOf(&wg)
+		if math.Abs(float64(got.Weight())-want.Weight()) > 1e-9 {
+			t.Fatalf("Expected weight %f but was %d", want.Weight(), got.Weight())
+		}
+		if len(got.Edges()) != len(want.Edges()) {
+			t.Fatalf("Expected %d edges but had %d", len(want.Edges()), len(got.Edges()))
+		}
+	}
+}
@@ -2,6 +2,8 @@ package mst
 
 import (
 	. "github.com/aybabtme/graph"
+	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -54,6 +56,100 @@ func TestLazyPrimMatchesKnownOutput(t *testing.T) {
 	testMstAgainstKnownOutput(t, BuildLazyPrimMST, expectedPrimMST)
 }
 
+func TestEagerPrimMatchesKnownOutput(t *testing.T) {
+	testMstAgainstKnownOutput(t, BuildEagerPrimMST, expectedPrimMST)
+}
+
+func TestEagerPrimBuildsForest(t *testing.T) {
+	// Two copies of the tiny graph, side by side
+	wg := NewWeightGraph(16)
+	for _, edge := range tinyEdgeWeightedGraph {
+		wg.AddEdge(edge)
+		v := edge.Either()
+		wg.AddEdge(NewEdge(v+8, edge.Other(v)+8, edge.Weight()))
+	}
+
+	forest := BuildEagerPrimMST(&wg)
+
+	if len(forest.Edges()) != 2*len(expectedPrimMST) {
+		t.Errorf("Expected forest of %d edges, got %d",
+			2*len(expectedPrimMST), len(forest.Edges()))
+	}
+
+	want := 2 * expectedWeight(expectedPrimMST)
+	if math.Abs(forest.Weight()-want) > 1e-9 {
+		t.Errorf("Expected weight of forest to be %f but was %f",
+			want, forest.Weight())
+	}
+}
+
+func TestBoruvkaMatchesKnownWeight(t *testing.T) {
+	testMstAgainstKnownWeight(t, BuildBoruvkaMST, expectedKruskalMST)
+	testMstAgainstKnownWeight(t, func(wg *WeightGraph) MST {
+		return BuildParallelBoruvkaMST(wg, 3)
+	}, expectedKruskalMST)
+}
+
+func TestMSTAlgorithmsAgree(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	builders := map[string]func(*WeightGraph) MST{
+		"Kruskal":    BuildKruskalMST,
+		"EagerPrim":  BuildEagerPrimMST,
+		"Boruvka":    BuildBoruvkaMST,
+		"ParBoruvka": func(wg *WeightGraph) MST { return BuildParallelBoruvkaMST(wg, 0) },
+	}
+
+	for i := 0; i < 50; i++ {
+		v := 1 + r.Intn(100)
+		wg := NewWeightGraph(v)
+		for e := r.Intn(4 * v); e > 0; e-- {
+			// few distinct weights, so that there are plenty of ties
+			wg.AddEdge(NewEdge(r.Intn(v), r.Intn(v), float64(r.Intn(10))))
+		}
+
+		want := BuildKruskalMST(&wg)
+		for name, build := range builders {
+			got := build(&wg)
+			if got.Weight() != want.Weight() {
+				t.Fatalf("%s: expected weight %f but was %f",
+					name, want.Weight(), got.Weight())
+			}
+			if len(got.Edges()) != len(want.Edges()) {
+				t.Fatalf("%s: expected %d edges but had %d",
+					name, len(want.Edges()), len(got.Edges()))
+			}
+		}
+	}
+}
+
+func testMstAgainstKnownWeight(t *testing.T, mstFunc func(*WeightGraph) MST, expectedMST []Edge) {
+	wg := NewWeightGraph(8)
+	for _, edge := range tinyEdgeWeightedGraph {
+		wg.AddEdge(edge)
+	}
+
+	mst := mstFunc(&wg)
+
+	if math.Abs(mst.Weight()-expectedWeight(expectedMST)) > 1e-9 {
+		t.Errorf("Expected weight of MST to be %f but was %f",
+			expectedWeight(expectedMST), mst.Weight())
+	}
+
+	want := make(map[Edge]bool)
+	for _, e := range expectedMST {
+		want[e] = true
+	}
+	for _, e := range mst.Edges() {
+		if !want[e] {
+			t.Errorf("Edge %#v is not in the MST", &e)
+		}
+	}
+	if len(mst.Edges()) != len(expectedMST) {
+		t.Errorf("Expected MST to have length %d but was %d",
+			len(expectedMST), len(mst.Edges()))
+	}
+}
+
 func testMstAgainstKnownOutput(t *testing.T, mstFunc func(*WeightGraph) MST, expectedMST []Edge) {
 	wg := NewWeightGraph(8)
 	for _, edge := range tinyEdgeWeightedGraph {
@@ -0,0 +1,42 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+)
+
+// AllPairsShortestPath answers queries of the form "What is the shortest
+// path from vertex v to vertex w?" in a weighted digraph.  When the digraph
+// has a negative cycle, shortest paths are not defined and HasPath, Dist and
+// Path panic.
+type AllPairsShortestPath interface {
+	// HasPath tells if vertex w is reachable from vertex v
+	HasPath(v, w int) bool
+	// Dist is the total weight of the shortest path from vertex v to vertex
+	// w, or +Inf if there is no such path
+	Dist(v, w int) float64
+	// Path is the shortest path from vertex v to vertex w, or an empty path
+	// if there is none
+	Path(v, w int) []int
+	// HasNegativeCycle tells if the digraph has a negative cycle
+	HasNegativeCycle() bool
+	// NegativeCycle returns the vertices of a negative cycle, if there is
+	// one.  The first and last vertices are the same, as in
+	// graph.DirectedCycle.
+	NegativeCycle() []int
+}
+
+// negativeCycleThrough finds a negative cycle reachable from vertex s, which
+// must exist.
+func negativeCycleThrough(wd *graph.EdgeWeightedDigraph, s int) []int {
+	cycle := BuildBellmanFord(wd, s).NegativeCycle()
+	if len(cycle) == 0 {
+		panic("no negative cycle reachable from the source")
+	}
+	return cycle
+}
+
+func mustNotHaveNegativeCycle(sp AllPairsShortestPath) {
+	if sp.HasNegativeCycle() {
+		panic("negative cost cycle exists")
+	}
+}
@@ -0,0 +1,149 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var allPairsBuilders = map[string]func(*graph.EdgeWeightedDigraph) AllPairsShortestPath{
+	"FloydWarshall": BuildFloydWarshall,
+	"Johnson":       BuildJohnson,
+}
+
+func TestAllPairsMatchesKnownOutput(t *testing.T) {
+	wd := graph.NewEdgeWeightedDigraph(8)
+	for _, e := range tinyEWDnEdges {
+		wd.AddEdge(graph.NewDirectedEdge(e.from, e.to, e.weight))
+	}
+
+	for name, build := range allPairsBuilders {
+		sp := build(&wd)
+		if sp.HasNegativeCycle() {
+			t.Fatalf("%s: should not have a negative cycle, got %v", name, sp.NegativeCycle())
+		}
+		for w, want := range tinyEWDnExpected {
+			if !sp.HasPath(0, w) {
+				t.Errorf("%s: should have a path from 0 to %d", name, w)
+				continue
+			}
+			if math.Abs(want.dist-sp.Dist(0, w)) > 1e-9 {
+				t.Errorf("%s: distance from 0 to %d, want %f got %f",
+					name, w, want.dist, sp.Dist(0, w))
+			}
+			compareIntSlices(t, want.path, sp.Path(0, w), name+": shortest path should match.")
+		}
+	}
+}
+
+func TestAllPairsMatchesBellmanFord(t *testing.T) {
+	r := rand.New(rand.NewSource(19))
+	for i := 0; i < 100; i++ {
+		v := 1 + r.Intn(15)
+		wd := graph.NewEdgeWeightedDigraph(v)
+		for e := r.Intn(4 * v); e > 0; e-- {
+			wd.AddEdge(graph.NewDirectedEdge(r.Intn(v), r.Intn(v), float64(r.Intn(20)-3)/4))
+		}
+
+		negative := false
+		sources := make([]NegativeCycleFinder, v)
+		for s := range sources {
+			sources[s] = BuildBellmanFord(&wd, s)
+			negative = negative || sources[s].HasNegativeCycle()
+		}
+
+		for name, build := range allPairsBuilders {
+			sp := build(&wd)
+			if sp.HasNegativeCycle() != negative {
+				t.Fatalf("%s: should tell negative cycle is %v in\n%s", name, negative, wd.GoString())
+			}
+			if negative {
+				checkNegativeCycle(t, name, &wd, sp.NegativeCycle())
+				continue
+			}
+			for s := range sources {
+				for w := 0; w < v; w++ {
+					checkAllPairsPath(t, name, &wd, sp, sources[s], s, w)
+				}
+			}
+		}
+	}
+}
+
+func checkAllPairsPath(t *testing.T, name string, wd *graph.EdgeWeightedDigraph,
+	sp AllPairsShortestPath, want ShortestPath, v, w int) {
+
+	if sp.HasPath(v, w) != want.HasPathTo(w) {
+		t.Fatalf("%s: path from %d to %d should be %v", name, v, w, want.HasPathTo(w))
+	}
+	if !want.HasPathTo(w) {
+		if !math.IsInf(sp.Dist(v, w), 1) || len(sp.Path(v, w)) != 0 {
+			t.Errorf("%s: should have no path from %d to %d", name, v, w)
+		}
+		return
+	}
+	if math.Abs(sp.Dist(v, w)-want.DistTo(w)) > 1e-9 {
+		t.Errorf("%s: distance from %d to %d, want %f got %f",
+			name, v, w, want.DistTo(w), sp.Dist(v, w))
+	}
+
+	path := sp.Path(v, w)
+	if path[0] != v || path[len(path)-1] != w {
+		t.Fatalf("%s: path from %d to %d is %v", name, v, w, path)
+	}
+	if weight := pathWeight(t, wd, path); math.Abs(weight-want.DistTo(w)) > 1e-9 {
+		t.Errorf("%s: path %v weighs %f but should be %f", name, path, weight, want.DistTo(w))
+	}
+}
+
+func checkNegativeCycle(t *testing.T, name string, wd *graph.EdgeWeightedDigraph, cycle []int) {
+	if len(cycle) < 2 || cycle[0] != cycle[len(cycle)-1] {
+		t.Fatalf("%s: %v is not a cycle", name, cycle)
+	}
+	if weight := pathWeight(t, wd, cycle); weight >= 0 {
+		t.Errorf("%s: cycle %v weighs %f, which isn't negative", name, cycle, weight)
+	}
+}
+
+// pathWeight is the weight of path, using the lightest edge between every two
+// of its vertices.
+func pathWeight(t *testing.T, wd *graph.EdgeWeightedDigraph, path []int) float64 {
+	total := 0.0
+	for i := 1; i < len(path); i++ {
+		lightest := math.Inf(1)
+		for _, e := range wd.Adj(path[i-1]) {
+			if e.To() == path[i] && e.Weight() < lightest {
+				lightest = e.Weight()
+			}
+		}
+		if math.IsInf(lightest, 1) {
+			t.Fatalf("No edge from %d to %d in path %v", path[i-1], path[i], path)
+		}
+		total += lightest
+	}
+	return total
+}
+
+func TestAllPairsPanicsOnNegativeCycle(t *testing.T) {
+	wd := graph.NewEdgeWeightedDigraph(3)
+	wd.AddEdge(graph.NewDirectedEdge(0, 1, 1.0))
+	wd.AddEdge(graph.NewDirectedEdge(2, 2, -0.5))
+
+	for name, build := range allPairsBuilders {
+		sp := build(&wd)
+		if !sp.HasNegativeCycle() {
+			t.Fatalf("%s: should have found the negative self-loop", name)
+		}
+		compareIntSlices(t, []int{2, 2}, sp.NegativeCycle(), name+": cycle should be the self-loop.")
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Dist should panic with a negative cycle", name)
+				}
+			}()
+			sp.Dist(0, 1)
+		}()
+	}
+}
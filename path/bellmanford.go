@@ -0,0 +1,136 @@
+package path
+
+import (
+	"container/list"
+	"github.com/aybabtme/graph"
+	"math"
+)
+
+// NegativeCycleFinder is a ShortestPath that can also tell whether a
+// negative cycle is reachable from its source.  When there is such a cycle,
+// shortest paths are not defined and HasPathTo, PathTo and DistTo panic.
+type NegativeCycleFinder interface {
+	ShortestPath
+	// HasNegativeCycle tells if a negative cycle is reachable from the
+	// source
+	HasNegativeCycle() bool
+	// NegativeCycle returns the vertices of a negative cycle reachable from
+	// the source, if there is one.  The first and last vertices are the
+	// same, as in graph.DirectedCycle.
+	NegativeCycle() []int
+}
+
+type bellmanFord struct {
+	from   int
+	distTo []float64
+	edgeTo []int
+	cycle  []int
+}
+
+// BuildBellmanFord builds the shortest paths tree of weighted digraph wd from
+// source s, using the queue-based Bellman-Ford algorithm.  Edges can have
+// negative weights.  This is O(EV) in the worst case, but typically
+// proportional to E + V, and extra space proportional to V.
+func BuildBellmanFord(wd *graph.EdgeWeightedDigraph, s int) NegativeCycleFinder {
+	b := bellmanFord{
+		from:   s,
+		distTo: make([]float64, wd.V()),
+		edgeTo: make([]int, wd.V()),
+	}
+
+	for v := range b.distTo {
+		b.distTo[v] = math.Inf(1)
+		b.edgeTo[v] = -1
+	}
+	b.distTo[s] = 0.0
+
+	onQueue := make([]bool, wd.V())
+	queue := list.New()
+	queue.PushBack(s)
+	onQueue[s] = true
+
+	cost := 0
+
+	relax := func(v int) {
+		for _, e := range wd.Adj(v) {
+			w := e.To()
+			if b.distTo[w] <= b.distTo[v]+e.Weight() {
+				continue
+			}
+			b.distTo[w] = b.distTo[v] + e.Weight()
+			b.edgeTo[w] = v
+			if !onQueue[w] {
+				queue.PushBack(w)
+				onQueue[w] = true
+			}
+		}
+
+		cost++
+		if cost%wd.V() == 0 {
+			b.findNegativeCycle()
+		}
+	}
+
+	for el := queue.Front(); queue.Len() != 0 && !b.HasNegativeCycle(); el = queue.Front() {
+		v, ok := queue.Remove(el).(int)
+		if !ok {
+			panic("Failed to assert type int")
+		}
+		onQueue[v] = false
+		relax(v)
+	}
+
+	return b
+}
+
+// findNegativeCycle looks for a cycle in the shortest paths tree, which
+// can only exist if there is a negative cycle.
+func (b *bellmanFord) findNegativeCycle() {
+	spt := graph.NewDigraph(len(b.edgeTo))
+	for w, v := range b.edgeTo {
+		if v != -1 {
+			spt.AddEdge(v, w)
+		}
+	}
+	b.cycle = graph.DirectedCycle(spt)
+}
+
+func (b bellmanFord) HasNegativeCycle() bool {
+	return len(b.cycle) != 0
+}
+
+func (b bellmanFord) NegativeCycle() []int {
+	return b.cycle
+}
+
+func (b bellmanFord) HasPathTo(to int) bool {
+	b.mustNotHaveNegativeCycle()
+	return !math.IsInf(b.distTo[to], 1)
+}
+
+func (b bellmanFord) DistTo(to int) float64 {
+	b.mustNotHaveNegativeCycle()
+	return b.distTo[to]
+}
+
+func (b bellmanFord) PathTo(to int) []int {
+	if !b.HasPathTo(to) {
+		return []int{}
+	}
+
+	var path []int
+	for next := to; next != b.from; next = b.edgeTo[next] {
+		path = append(path, next)
+	}
+	path = append(path, b.from)
+
+	reverse(path)
+
+	return path
+}
+
+func (b bellmanFord) mustNotHaveNegativeCycle() {
+	if b.HasNegativeCycle() {
+		panic("negative cost cycle exists")
+	}
+}
@@ -0,0 +1,125 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+	"math"
+	"testing"
+)
+
+var (
+	// The tinyEWDn digraph from Algorithms 4th Ed, which has negative
+	// weights but no negative cycle
+	tinyEWDnEdges = []struct {
+		from, to int
+		weight   float64
+	}{
+		{4, 5, 0.35}, {5, 4, 0.35}, {4, 7, 0.37}, {5, 7, 0.28}, {7, 5, 0.28},
+		{5, 1, 0.32}, {0, 4, 0.38}, {0, 2, 0.26}, {7, 3, 0.39}, {1, 3, 0.29},
+		{2, 7, 0.34}, {6, 2, -1.20}, {3, 6, 0.52}, {6, 0, -1.40}, {6, 4, -1.25},
+	}
+	// We know the shortest paths from 0 to be
+	tinyEWDnExpected = []struct {
+		dist float64
+		path []int
+	}{
+		/* 0 */ {0.00, []int{0}},
+		/* 1 */ {0.93, []int{0, 2, 7, 3, 6, 4, 5, 1}},
+		/* 2 */ {0.26, []int{0, 2}},
+		/* 3 */ {0.99, []int{0, 2, 7, 3}},
+		/* 4 */ {0.26, []int{0, 2, 7, 3, 6, 4}},
+		/* 5 */ {0.61, []int{0, 2, 7, 3, 6, 4, 5}},
+		/* 6 */ {1.51, []int{0, 2, 7, 3, 6}},
+		/* 7 */ {0.60, []int{0, 2, 7}},
+	}
+)
+
+func TestBellmanFordMatchesDijkstra(t *testing.T) {
+	sp := BuildBellmanFord(tinyEWD(), 0)
+	if sp.HasNegativeCycle() {
+		t.Fatalf("Should not have a negative cycle, got %v", sp.NegativeCycle())
+	}
+	checkShortestPaths(t, sp)
+}
+
+func TestBellmanFordWithNegativeWeights(t *testing.T) {
+	wd := graph.NewEdgeWeightedDigraph(8)
+	for _, e := range tinyEWDnEdges {
+		wd.AddEdge(graph.NewDirectedEdge(e.from, e.to, e.weight))
+	}
+
+	sp := BuildBellmanFord(&wd, 0)
+	if sp.HasNegativeCycle() {
+		t.Fatalf("Should not have a negative cycle, got %v", sp.NegativeCycle())
+	}
+
+	for v, want := range tinyEWDnExpected {
+		if math.Abs(want.dist-sp.DistTo(v)) > 1e-9 {
+			t.Errorf("Distance to %d, want %f got %f", v, want.dist, sp.DistTo(v))
+		}
+		compareIntSlices(t, want.path, sp.PathTo(v), "Shortest path should match.")
+	}
+}
+
+func TestBellmanFordFindsNegativeCycle(t *testing.T) {
+	// 0 -> 1 -> 2 -> 3 -> 1 is reachable from 0 and weighs -0.5
+	wd := graph.NewEdgeWeightedDigraph(5)
+	wd.AddEdge(graph.NewDirectedEdge(0, 1, 1.0))
+	wd.AddEdge(graph.NewDirectedEdge(1, 2, 1.0))
+	wd.AddEdge(graph.NewDirectedEdge(2, 3, 0.5))
+	wd.AddEdge(graph.NewDirectedEdge(3, 1, -2.0))
+	wd.AddEdge(graph.NewDirectedEdge(3, 4, 1.0))
+
+	sp := BuildBellmanFord(&wd, 0)
+	if !sp.HasNegativeCycle() {
+		t.Fatalf("Should have found a negative cycle")
+	}
+
+	cycle := sp.NegativeCycle()
+	if len(cycle) != 4 {
+		t.Fatalf("Expected cycle of 3 edges, got %v", cycle)
+	}
+	if cycle[0] != cycle[len(cycle)-1] {
+		t.Errorf("Cycle should start and end on the same vertex, got %v", cycle)
+	}
+
+	weight := 0.0
+	for i := 0; i < len(cycle)-1; i++ {
+		found := false
+		for _, e := range wd.Adj(cycle[i]) {
+			if e.To() == cycle[i+1] {
+				weight += e.Weight()
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("No edge %d->%d in cycle %v", cycle[i], cycle[i+1], cycle)
+		}
+	}
+	if weight >= 0 {
+		t.Errorf("Cycle %v should have negative weight, was %f", cycle, weight)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked asking for a distance")
+		}
+	}()
+	sp.DistTo(4)
+}
+
+func TestBellmanFordIgnoresUnreachableNegativeCycle(t *testing.T) {
+	wd := graph.NewEdgeWeightedDigraph(4)
+	wd.AddEdge(graph.NewDirectedEdge(0, 1, 1.0))
+	wd.AddEdge(graph.NewDirectedEdge(2, 3, -1.0))
+	wd.AddEdge(graph.NewDirectedEdge(3, 2, -1.0))
+
+	sp := BuildBellmanFord(&wd, 0)
+	if sp.HasNegativeCycle() {
+		t.Fatalf("Should not have a reachable negative cycle, got %v",
+			sp.NegativeCycle())
+	}
+	if sp.HasPathTo(2) {
+		t.Errorf("Should not have a path to 2")
+	}
+}
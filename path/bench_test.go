@@ -0,0 +1,102 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+	"math/rand"
+	"testing"
+)
+
+type sccBuilder func(graph.Digraph) SCC
+
+var benchDigraphs = map[int]graph.Digraph{}
+
+// benchDigraph gives a random digraph with v vertices and 4v edges, made
+// only once per size.
+func benchDigraph(v int) graph.Digraph {
+	di, ok := benchDigraphs[v]
+	if !ok {
+		di = randomDigraph(rand.New(rand.NewSource(int64(v))), v, 4*v)
+		benchDigraphs[v] = di
+	}
+	return di
+}
+
+func Benchmark_Kosaraju_1k(b *testing.B)   { benchmarkSCC(BuildSCC, 1000, b) }
+func Benchmark_Kosaraju_100k(b *testing.B) { benchmarkSCC(BuildSCC, 100000, b) }
+func Benchmark_Kosaraju_1M(b *testing.B)   { benchmarkSCC(BuildSCC, 1000000, b) }
+
+func Benchmark_Tarjan_1k(b *testing.B)   { benchmarkSCC(BuildTarjanSCC, 1000, b) }
+func Benchmark_Tarjan_100k(b *testing.B) { benchmarkSCC(BuildTarjanSCC, 100000, b) }
+func Benchmark_Tarjan_1M(b *testing.B)   { benchmarkSCC(BuildTarjanSCC, 1000000, b) }
+
+func benchmarkSCC(build sccBuilder, v int, b *testing.B) {
+	di := benchDigraph(v)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = build(di)
+	}
+}
+
+func Benchmark_TransitiveClosure_1k(b *testing.B) {
+	benchmarkClosure(func(di graph.Digraph) Reachability { return BuildTransitiveClosure(di) }, 1000, b)
+}
+func Benchmark_BitsetClosure_1k(b *testing.B)     { benchmarkClosure(BuildBitsetClosure, 1000, b) }
+func Benchmark_BitsetClosure_100k(b *testing.B)   { benchmarkClosure(BuildBitsetClosure, 100000, b) }
+func Benchmark_IntervalClosure_1k(b *testing.B)   { benchmarkClosure(BuildIntervalClosure, 1000, b) }
+func Benchmark_IntervalClosure_100k(b *testing.B) { benchmarkClosure(BuildIntervalClosure, 100000, b) }
+
+func benchmarkClosure(build func(graph.Digraph) Reachability, v int, b *testing.B) {
+	di := benchDigraph(v)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = build(di)
+	}
+}
+
+// benchUngraphs are random graphs with v vertices and 4v edges, both as an
+// Ungraph and packed as a CSRGraph, made only once per size.
+var benchUngraphs = map[int][2]graph.Graph{}
+
+func benchUngraph(v int) (graph.Ungraph, graph.CSRGraph) {
+	gs, ok := benchUngraphs[v]
+	if !ok {
+		r := rand.New(rand.NewSource(int64(v)))
+		g := graph.NewGraph(v)
+		for e := 0; e < 4*v; e++ {
+			g.AddEdge(r.Intn(v), r.Intn(v))
+		}
+		gs = [2]graph.Graph{g, graph.BuildCSR(g)}
+		benchUngraphs[v] = gs
+	}
+	return gs[0].(graph.Ungraph), gs[1].(graph.CSRGraph)
+}
+
+func Benchmark_BFS_Ungraph_1M(b *testing.B) {
+	g, _ := benchUngraph(1000000)
+	benchmarkTraversal(func() { BuildBFS(g, 0) }, b)
+}
+
+func Benchmark_BFS_CSR_1M(b *testing.B) {
+	_, c := benchUngraph(1000000)
+	benchmarkTraversal(func() { BuildBFS(c, 0) }, b)
+}
+
+func Benchmark_CC_Ungraph_1M(b *testing.B) {
+	g, _ := benchUngraph(1000000)
+	benchmarkTraversal(func() { BuildCC(g) }, b)
+}
+
+func Benchmark_CC_CSR_1M(b *testing.B) {
+	_, c := benchUngraph(1000000)
+	benchmarkTraversal(func() { BuildCC(c) }, b)
+}
+
+func benchmarkTraversal(traverse func(), b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		traverse()
+	}
+}
@@ -11,7 +11,8 @@ type TransitiveClosure struct {
 }
 
 // BuildTransitiveClosure builds a model of all-pair reachable vertices using
-// depth-first search path finders.
+// depth-first search path finders.  This needs extra space proportional to
+// V^2, BuildBitsetClosure and BuildIntervalClosure are far more compact.
 func BuildTransitiveClosure(di graph.Digraph) *TransitiveClosure {
 	tc := &TransitiveClosure{paths: make([]PathFinder, di.V())}
 
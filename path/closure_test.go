@@ -2,6 +2,7 @@ package path
 
 import (
 	"github.com/aybabtme/graph"
+	"math/rand"
 	"testing"
 )
 
@@ -80,21 +81,51 @@ var (
 	}
 )
 
+var reachabilityBuilders = map[string]func(graph.Digraph) Reachability{
+	"TransitiveClosure": func(di graph.Digraph) Reachability { return BuildTransitiveClosure(di) },
+	"BitsetClosure":     BuildBitsetClosure,
+	"IntervalClosure":   BuildIntervalClosure,
+}
+
 func TestTransitiveClosureMatchesOutput(t *testing.T) {
 	di := graph.NewDigraph(13)
 	for _, edge := range tcGraphEdges {
 		di.AddEdge(edge.from, edge.to)
 	}
 
-	tc := BuildTransitiveClosure(di)
+	for name, build := range reachabilityBuilders {
+		tc := build(di)
+
+		for v := 0; v < di.V(); v++ {
+			for w := 0; w < di.V(); w++ {
+				expected := tcReachableExpected[v][w]
+				actual := tc.Reachable(v, w)
+				if expected != actual {
+					t.Errorf("%s: expected reachability from %d to %d to be %v but was %v",
+						name, v, w, expected, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestClosuresMatchDFS(t *testing.T) {
+	r := rand.New(rand.NewSource(20))
+	for i := 0; i < 200; i++ {
+		v := 1 + r.Intn(80)
+		// sparse enough to have many components in the condensation
+		di := randomDigraph(r, v, r.Intn(2*v))
 
-	for v := 0; v < di.V(); v++ {
-		for w := 0; w < di.V(); w++ {
-			expected := tcReachableExpected[v][w]
-			actual := tc.Reachable(v, w)
-			if expected != actual {
-				t.Errorf("Expected reachability from %d to %d to be %v but was %v",
-					v, w, expected, actual)
+		for name, build := range reachabilityBuilders {
+			tc := build(di)
+			for s := 0; s < v; s++ {
+				dfs := BuildDFS(di, s)
+				for w := 0; w < v; w++ {
+					if tc.Reachable(s, w) != dfs.HasPathTo(w) {
+						t.Fatalf("%s: expected reachability from %d to %d to be %v in\n%s",
+							name, s, w, dfs.HasPathTo(w), di.GoString())
+					}
+				}
 			}
 		}
 	}
@@ -29,21 +29,11 @@ func BuildCC(g graph.Graph) CC {
 
 	marked := make([]bool, g.V())
 
-	var visit func(v int)
-
-	visit = func(v int) {
-		marked[v] = true
-		cc.id[v] = cc.count
-		for _, adj := range g.Adj(v) {
-			if !marked[adj] {
-				visit(adj)
-			}
-		}
-	}
-
 	for v := 0; v < g.V(); v++ {
 		if !marked[v] {
-			visit(v)
+			visitAll(g, v, marked, func(w int) {
+				cc.id[w] = cc.count
+			})
 			cc.count++
 		}
 	}
@@ -89,22 +79,12 @@ func BuildSCC(di graph.Digraph) SCC {
 
 	marked := make([]bool, di.V())
 
-	var dfs func(v int)
-
-	dfs = func(v int) {
-		marked[v] = true
-		scc.id[v] = scc.count
-		for _, adj := range di.Adj(v) {
-			if !marked[adj] {
-				dfs(adj)
-			}
-		}
-	}
-
 	dfo := BuildDFO(di.Reverse())
 	for _, v := range dfo.ReversePost {
 		if !marked[v] {
-			dfs(v)
+			visitAll(di, v, marked, func(w int) {
+				scc.id[w] = scc.count
+			})
 			scc.count++
 		}
 	}
@@ -141,25 +121,34 @@ func BuildDFO(di graph.Digraph) *DFO {
 	dfo := &DFO{}
 	marked := make([]bool, di.V())
 
-	var dfs func(int)
-
-	dfs = func(v int) {
-		dfo.Pre = append(dfo.Pre, v)
+	var stack []dfsFrame
 
-		marked[v] = true
-		for _, adj := range di.Adj(v) {
-			if !marked[adj] {
-				dfs(adj)
-			}
+	for s := 0; s < di.V(); s++ {
+		if marked[s] {
+			continue
 		}
+		marked[s] = true
+		dfo.Pre = append(dfo.Pre, s)
+		stack = append(stack, dfsFrame{v: s})
+
+		for len(stack) != 0 {
+			f := &stack[len(stack)-1]
+			v := f.v
+			adj := di.Adj(v)
+			if f.next == len(adj) {
+				stack = stack[:len(stack)-1]
+				dfo.Post = append(dfo.Post, v)
+				dfo.ReversePost = append(dfo.ReversePost, v)
+				continue
+			}
+			w := adj[f.next]
+			f.next++
 
-		dfo.Post = append(dfo.Post, v)
-		dfo.ReversePost = append(dfo.ReversePost, v)
-	}
-
-	for i := 0; i < di.V(); i++ {
-		if !marked[i] {
-			dfs(i)
+			if !marked[w] {
+				marked[w] = true
+				dfo.Pre = append(dfo.Pre, w)
+				stack = append(stack, dfsFrame{v: w})
+			}
 		}
 	}
 
@@ -2,6 +2,7 @@ package path
 
 import (
 	"github.com/aybabtme/graph"
+	"math/rand"
 	"testing"
 )
 
@@ -195,11 +196,20 @@ var (
 )
 
 func TestSCCMatchesKnownOutput(t *testing.T) {
+	for _, build := range []func(graph.Digraph) SCC{
+		BuildSCC,
+		BuildTarjanSCC,
+	} {
+		sccHarness(t, build)
+	}
+}
+
+func sccHarness(t *testing.T, build func(graph.Digraph) SCC) {
 	di := graph.NewDigraph(13)
 	for _, edge := range sccGraphEdges {
 		di.AddEdge(edge.from, edge.to)
 	}
-	scc := BuildSCC(di)
+	scc := build(di)
 
 	expectedCount := len(sccExpected)
 	actualCount := scc.Count()
@@ -225,3 +235,62 @@ func TestSCCMatchesKnownOutput(t *testing.T) {
 		}
 	}
 }
+
+func TestTarjanSCCAgreesWithKosaraju(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 20; i++ {
+		di := randomDigraph(r, 200, 300)
+		kosaraju := BuildSCC(di)
+		tarjan := BuildTarjanSCC(di)
+
+		if kosaraju.Count() != tarjan.Count() {
+			t.Fatalf("Kosaraju found %d components but Tarjan found %d",
+				kosaraju.Count(), tarjan.Count())
+		}
+		for v := 0; v < di.V(); v++ {
+			for w := 0; w < di.V(); w++ {
+				if kosaraju.StronglyConnected(v, w) != tarjan.StronglyConnected(v, w) {
+					t.Fatalf("Kosaraju and Tarjan disagree on %d and %d", v, w)
+				}
+			}
+		}
+	}
+}
+
+func randomDigraph(r *rand.Rand, v, e int) graph.Digraph {
+	di := graph.NewDigraph(v)
+	for i := 0; i < e; i++ {
+		di.AddEdge(r.Intn(v), r.Intn(v))
+	}
+	return di
+}
+
+func TestDeepComponents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Not running test on deep graphs")
+	}
+	di := deepDigraph(deepGraphSize)
+
+	if cc := BuildCC(di); cc.Count() != 1 {
+		t.Errorf("Path digraph should have 1 component, had %d", cc.Count())
+	}
+
+	dfo := BuildDFO(di)
+	for i := 0; i < deepGraphSize; i++ {
+		if dfo.Pre[i] != i || dfo.ReversePost[i] != i {
+			t.Fatalf("Orders of path digraph should be the path at %d, "+
+				"pre=%d reversePost=%d", i, dfo.Pre[i], dfo.ReversePost[i])
+		}
+	}
+
+	di.AddEdge(deepGraphSize-1, 0)
+	for _, build := range []func(graph.Digraph) SCC{
+		BuildSCC,
+		BuildTarjanSCC,
+	} {
+		if scc := build(di); scc.Count() != 1 {
+			t.Errorf("Closed path digraph should have 1 component, had %d",
+				scc.Count())
+		}
+	}
+}
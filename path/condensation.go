@@ -0,0 +1,44 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+)
+
+// BuildCondensation builds the condensation of digraph di, given its
+// strongly connected components scc.  The condensation is a DAG with one
+// vertex per component of scc, and an edge from component c to component d
+// whenever di has an edge from a vertex of c to a vertex of d.  Vertex c of
+// the DAG is the component of ID c, whose vertices are listed, in increasing
+// order, in members[c].
+func BuildCondensation(di graph.Digraph, scc SCC) (dag graph.DAG, members [][]int) {
+	members = make([][]int, scc.Count())
+	for v := 0; v < di.V(); v++ {
+		c := scc.ID(v)
+		members[c] = append(members[c], v)
+	}
+
+	condensed := graph.NewDigraph(scc.Count())
+
+	// lastFrom[d] is the last component found to have an edge to d, so
+	// that parallel edges between components are only added once
+	lastFrom := make([]int, scc.Count())
+	for d := range lastFrom {
+		lastFrom[d] = -1
+	}
+
+	for c, comp := range members {
+		for _, v := range comp {
+			for _, w := range di.Adj(v) {
+				d := scc.ID(w)
+				if d != c && lastFrom[d] != c {
+					lastFrom[d] = c
+					condensed.AddEdge(c, d)
+				}
+			}
+		}
+	}
+
+	// Components can't form a cycle, otherwise they would be the same
+	// component.
+	return graph.DAG{Digraph: &condensed}, members
+}
@@ -0,0 +1,65 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+	"testing"
+)
+
+func TestCondensationMatchesSCC(t *testing.T) {
+	di := graph.NewDigraph(13)
+	for _, edge := range sccGraphEdges {
+		di.AddEdge(edge.from, edge.to)
+	}
+	scc := BuildSCC(di)
+
+	dag, members := BuildCondensation(di, scc)
+
+	if dag.V() != len(sccExpected) {
+		t.Fatalf("Expected %d vertices in condensation, got %d",
+			len(sccExpected), dag.V())
+	}
+
+	for _, expected := range sccExpected {
+		compareIntSlices(t, expected.comp, members[expected.id],
+			"Members of component should match.")
+	}
+
+	// Components are {1}, {0,2,3,4,5}, {9,10,11,12}, {6,8} and {7}
+	expectedEdges := map[[2]int]bool{
+		{1, 0}: true,
+		{2, 1}: true,
+		{3, 1}: true, {3, 2}: true,
+		{4, 3}: true, {4, 2}: true,
+	}
+
+	if dag.E() != len(expectedEdges) {
+		t.Errorf("Expected %d edges in condensation, got %d:\n%s",
+			len(expectedEdges), dag.E(), dag.GoString())
+	}
+	for c := 0; c < dag.V(); c++ {
+		for _, d := range dag.Adj(c) {
+			if !expectedEdges[[2]int{c, d}] {
+				t.Errorf("Unexpected edge %d->%d in condensation", c, d)
+			}
+		}
+	}
+
+	if _, err := graph.NewDAG(*dag.Digraph); err != nil {
+		t.Errorf("Condensation should be acyclic, %v", err)
+	}
+
+	// Every edge of the condensation must go forward in topological order
+	order := dag.Sort()
+	position := make([]int, len(order))
+	for i, c := range order {
+		position[c] = i
+	}
+	for v := 0; v < di.V(); v++ {
+		for _, w := range di.Adj(v) {
+			if position[scc.ID(v)] > position[scc.ID(w)] {
+				t.Errorf("Edge %d->%d goes backward in topological order %v",
+					v, w, order)
+			}
+		}
+	}
+}
@@ -22,19 +22,26 @@ func BuildDFS(g graph.Graph, s int) PathFinder {
 		edgeTo: make([]int, g.V()),
 	}
 
-	var visit func(v int)
-
-	visit = func(v int) {
-		t.marked[v] = true
-		for _, adj := range g.Adj(v) {
-			if !t.marked[adj] {
-				t.edgeTo[adj] = v
-				visit(adj)
-			}
+	t.marked[t.from] = true
+	stack := []dfsFrame{{v: t.from}}
+
+	for len(stack) != 0 {
+		f := &stack[len(stack)-1]
+		v := f.v
+		adj := g.Adj(v)
+		if f.next == len(adj) {
+			stack = stack[:len(stack)-1]
+			continue
 		}
-	}
+		w := adj[f.next]
+		f.next++
 
-	visit(t.from)
+		if !t.marked[w] {
+			t.marked[w] = true
+			t.edgeTo[w] = v
+			stack = append(stack, dfsFrame{v: w})
+		}
+	}
 
 	return t
 }
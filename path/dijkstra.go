@@ -0,0 +1,88 @@
+package path
+
+import (
+	"fmt"
+	"github.com/aybabtme/graph"
+	"github.com/aybabtme/graph/internal/pq"
+	"math"
+)
+
+// ShortestPath holds information regarding the shortest paths in a weighted
+// digraph from a source.
+type ShortestPath interface {
+	PathFinder
+	// DistTo is the total weight of the shortest path from the source to the
+	// destination, or +Inf if there is no such path
+	DistTo(destination int) float64
+}
+
+type dijkstra struct {
+	from   int
+	distTo []float64
+	edgeTo []int
+}
+
+// BuildDijkstra builds the shortest paths tree of weighted digraph wd from
+// source s, using Dijkstra's algorithm.  This is O(E log V) and extra space
+// proportional to V.  It returns an error if wd has an edge of negative
+// weight.
+func BuildDijkstra(wd *graph.EdgeWeightedDigraph, s int) (ShortestPath, error) {
+	for _, e := range wd.Edges() {
+		if e.Weight() < 0 {
+			return nil, fmt.Errorf("edge %#v has negative weight", &e)
+		}
+	}
+
+	d := dijkstra{
+		from:   s,
+		distTo: make([]float64, wd.V()),
+		edgeTo: make([]int, wd.V()),
+	}
+
+	for v := range d.distTo {
+		d.distTo[v] = math.Inf(1)
+	}
+	d.distTo[s] = 0.0
+
+	queue := pq.NewIndexMin(d.distTo)
+	queue.Update(s)
+
+	for queue.Len() != 0 {
+		v := queue.PopMin()
+		for _, e := range wd.Adj(v) {
+			w := e.To()
+			if d.distTo[w] <= d.distTo[v]+e.Weight() {
+				continue
+			}
+			d.distTo[w] = d.distTo[v] + e.Weight()
+			d.edgeTo[w] = v
+			queue.Update(w)
+		}
+	}
+
+	return d, nil
+}
+
+func (d dijkstra) HasPathTo(to int) bool {
+	return !math.IsInf(d.distTo[to], 1)
+}
+
+func (d dijkstra) DistTo(to int) float64 {
+	return d.distTo[to]
+}
+
+func (d dijkstra) PathTo(to int) []int {
+	if !d.HasPathTo(to) {
+		return []int{}
+	}
+
+	var path []int
+	for next := to; next != d.from; next = d.edgeTo[next] {
+		path = append(path, next)
+	}
+	path = append(path, d.from)
+
+	reverse(path)
+
+	return path
+}
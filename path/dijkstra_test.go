@@ -0,0 +1,92 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+	"math"
+	"testing"
+)
+
+var (
+	// The tinyEWD digraph from Algorithms 4th Ed.
+	tinyEWDEdges = []struct {
+		from, to int
+		weight   float64
+	}{
+		{4, 5, 0.35}, {5, 4, 0.35}, {4, 7, 0.37}, {5, 7, 0.28}, {7, 5, 0.28},
+		{5, 1, 0.32}, {0, 4, 0.38}, {0, 2, 0.26}, {7, 3, 0.39}, {1, 3, 0.29},
+		{2, 7, 0.34}, {6, 2, 0.40}, {3, 6, 0.52}, {6, 0, 0.58}, {6, 4, 0.93},
+	}
+	// We know the shortest paths from 0 to be
+	tinyEWDExpected = []struct {
+		dist float64
+		path []int
+	}{
+		/* 0 */ {0.00, []int{0}},
+		/* 1 */ {1.05, []int{0, 4, 5, 1}},
+		/* 2 */ {0.26, []int{0, 2}},
+		/* 3 */ {0.99, []int{0, 2, 7, 3}},
+		/* 4 */ {0.38, []int{0, 4}},
+		/* 5 */ {0.73, []int{0, 4, 5}},
+		/* 6 */ {1.51, []int{0, 2, 7, 3, 6}},
+		/* 7 */ {0.60, []int{0, 2, 7}},
+	}
+)
+
+func tinyEWD() *graph.EdgeWeightedDigraph {
+	wd := graph.NewEdgeWeightedDigraph(8)
+	for _, e := range tinyEWDEdges {
+		wd.AddEdge(graph.NewDirectedEdge(e.from, e.to, e.weight))
+	}
+	return &wd
+}
+
+func TestDijkstraMatchesKnownOutput(t *testing.T) {
+	sp, err := BuildDijkstra(tinyEWD(), 0)
+	if err != nil {
+		t.Fatalf("Should have built shortest paths, %v", err)
+	}
+	checkShortestPaths(t, sp)
+}
+
+func TestDijkstraUnreachable(t *testing.T) {
+	wd := graph.NewEdgeWeightedDigraph(3)
+	wd.AddEdge(graph.NewDirectedEdge(0, 1, 1.0))
+	wd.AddEdge(graph.NewDirectedEdge(2, 0, 1.0))
+
+	sp, err := BuildDijkstra(&wd, 0)
+	if err != nil {
+		t.Fatalf("Should have built shortest paths, %v", err)
+	}
+
+	if sp.HasPathTo(2) {
+		t.Errorf("Should not have a path to 2, got %v", sp.PathTo(2))
+	}
+	if !math.IsInf(sp.DistTo(2), 1) {
+		t.Errorf("Distance to 2 should be +Inf, was %f", sp.DistTo(2))
+	}
+	if len(sp.PathTo(2)) != 0 {
+		t.Errorf("Path to 2 should be empty, was %v", sp.PathTo(2))
+	}
+}
+
+func TestDijkstraRefusesNegativeWeights(t *testing.T) {
+	wd := graph.NewEdgeWeightedDigraph(2)
+	wd.AddEdge(graph.NewDirectedEdge(0, 1, -1.0))
+
+	if _, err := BuildDijkstra(&wd, 0); err == nil {
+		t.Errorf("Should have refused a negative weight")
+	}
+}
+
+func checkShortestPaths(t *testing.T, sp ShortestPath) {
+	for v, want := range tinyEWDExpected {
+		if !sp.HasPathTo(v) {
+			t.Errorf("Should have a path to %d", v)
+			continue
+		}
+		if math.Abs(want.dist-sp.DistTo(v)) > 1e-9 {
+			t.Errorf("Distance to %d, want %f got %f", v, want.dist, sp.DistTo(v))
+		}
+		compareIntSlices(t, want.path, sp.PathTo(v), "Shortest path should match.")
+	}
+}
@@ -0,0 +1,224 @@
+package path
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aybabtme/graph"
+)
+
+var (
+	// ErrDegreeImbalance is returned when the degrees of the vertices of a
+	// graph don't allow an Euler path or circuit.
+	ErrDegreeImbalance = errors.New("degrees are imbalanced")
+	// ErrDisconnectedEdges is returned when the edges of a graph aren't all
+	// in the same component, so no single walk can use them all.
+	ErrDisconnectedEdges = errors.New("edges are disconnected")
+)
+
+// EulerEdge is an edge used by an Euler walk, going from From to To.  It is
+// the edge at Index in the adjacency list of From, which tells parallel
+// edges apart.
+type EulerEdge struct {
+	From, To, Index int
+}
+
+// EulerCircuit returns a circuit of graph g that uses each of its edges
+// exactly once, as the sequence of edges it follows.  The last edge goes
+// back to the start of the first.  If there is no such circuit, the error
+// wraps ErrDegreeImbalance or ErrDisconnectedEdges.
+func EulerCircuit(g graph.Ungraph) ([]EulerEdge, error) {
+	return eulerWalk(g, true)
+}
+
+// EulerPath returns a path of graph g that uses each of its edges exactly
+// once, as the sequence of edges it follows.  If there is no such path, the
+// error wraps ErrDegreeImbalance or ErrDisconnectedEdges.
+func EulerPath(g graph.Ungraph) ([]EulerEdge, error) {
+	return eulerWalk(g, false)
+}
+
+func eulerWalk(g graph.Ungraph, circuit bool) ([]EulerEdge, error) {
+	if g.E() == 0 {
+		return []EulerEdge{}, nil
+	}
+
+	start := -1
+	var odd []int
+	for v := 0; v < g.V(); v++ {
+		deg := graph.Degree(g, v)
+		if deg%2 == 1 {
+			odd = append(odd, v)
+		}
+		if start == -1 && deg != 0 {
+			start = v
+		}
+	}
+	switch {
+	case circuit && len(odd) != 0:
+		return nil, fmt.Errorf("%w, %d vertices have odd degree, such as %d",
+			ErrDegreeImbalance, len(odd), odd[0])
+	case len(odd) > 2:
+		return nil, fmt.Errorf("%w, %d vertices have odd degree but a path allows 2",
+			ErrDegreeImbalance, len(odd))
+	case len(odd) == 2:
+		start = odd[0]
+	}
+
+	cc := BuildCC(g)
+	for v := 0; v < g.V(); v++ {
+		if graph.Degree(g, v) != 0 && !cc.Connected(start, v) {
+			return nil, fmt.Errorf("%w, %d and %d have edges but aren't connected",
+				ErrDisconnectedEdges, start, v)
+		}
+	}
+
+	// Each edge appears in the adjacency lists of both its vertices, so
+	// number them to know which ones were used.
+	ids := undirectedEdgeIDs(g)
+	used := make([]bool, g.E())
+
+	return hierholzer(g.V(), start, func(v int, next *int) (EulerEdge, bool) {
+		adj := g.Adj(v)
+		for ; *next < len(adj); *next++ {
+			e := ids[v][*next]
+			if !used[e] {
+				used[e] = true
+				return EulerEdge{From: v, To: adj[*next], Index: *next}, true
+			}
+		}
+		return EulerEdge{}, false
+	}), nil
+}
+
+// undirectedEdgeIDs numbers the edges of g, where ids[v][i] is the number of
+// the edge at g.Adj(v)[i].  Both ends of an edge, and both entries of a
+// self-loop, have the same number.
+func undirectedEdgeIDs(g graph.Ungraph) (ids [][]int) {
+	ids = make([][]int, g.V())
+	pending := make(map[[2]int][]int)
+	count := 0
+
+	for v := 0; v < g.V(); v++ {
+		ids[v] = make([]int, len(g.Adj(v)))
+		for i, w := range g.Adj(v) {
+			key := [2]int{v, w}
+			if w < v {
+				key = [2]int{w, v}
+			}
+			waiting := pending[key]
+
+			// v sees the edge before w does, a self-loop sees itself twice
+			if w < v || (w == v && len(waiting) != 0) {
+				ids[v][i] = waiting[len(waiting)-1]
+				pending[key] = waiting[:len(waiting)-1]
+				continue
+			}
+
+			ids[v][i] = count
+			pending[key] = append(waiting, count)
+			count++
+		}
+	}
+	return ids
+}
+
+// DirectedEulerCircuit returns a circuit of digraph di that uses each of its
+// edges exactly once, as the sequence of edges it follows.  The last edge
+// goes back to the start of the first.  If there is no such circuit, the
+// error wraps ErrDegreeImbalance or ErrDisconnectedEdges.
+func DirectedEulerCircuit(di graph.Digraph) ([]EulerEdge, error) {
+	return directedEulerWalk(di, true)
+}
+
+// DirectedEulerPath returns a path of digraph di that uses each of its edges
+// exactly once, as the sequence of edges it follows.  If there is no such
+// path, the error wraps ErrDegreeImbalance or ErrDisconnectedEdges.
+func DirectedEulerPath(di graph.Digraph) ([]EulerEdge, error) {
+	return directedEulerWalk(di, false)
+}
+
+func directedEulerWalk(di graph.Digraph, circuit bool) ([]EulerEdge, error) {
+	if di.E() == 0 {
+		return []EulerEdge{}, nil
+	}
+
+	inDegree := make([]int, di.V())
+	for v := 0; v < di.V(); v++ {
+		for _, w := range di.Adj(v) {
+			inDegree[w]++
+		}
+	}
+
+	start, end := -1, -1
+	for v := 0; v < di.V(); v++ {
+		out := graph.Degree(di, v)
+		switch {
+		case out == inDegree[v]:
+			continue
+		case circuit:
+			return nil, fmt.Errorf("%w, %d has in-degree %d but out-degree %d",
+				ErrDegreeImbalance, v, inDegree[v], out)
+		case out == inDegree[v]+1 && start == -1:
+			start = v
+		case out+1 == inDegree[v] && end == -1:
+			end = v
+		default:
+			return nil, fmt.Errorf("%w, %d has in-degree %d but out-degree %d",
+				ErrDegreeImbalance, v, inDegree[v], out)
+		}
+	}
+	if (start == -1) != (end == -1) {
+		return nil, fmt.Errorf("%w, a path needs one more edge out of its start and into its end",
+			ErrDegreeImbalance)
+	}
+	for v := 0; start == -1; v++ {
+		if graph.Degree(di, v) != 0 {
+			start = v
+		}
+	}
+
+	walk := hierholzer(di.V(), start, func(v int, next *int) (EulerEdge, bool) {
+		adj := di.Adj(v)
+		if *next == len(adj) {
+			return EulerEdge{}, false
+		}
+		*next++
+		return EulerEdge{From: v, To: adj[*next-1], Index: *next - 1}, true
+	})
+
+	// With balanced degrees, the walk only misses edges that can't be
+	// reached from its start.
+	if len(walk) != di.E() {
+		return nil, fmt.Errorf("%w, the walk from %d only uses %d of %d edges",
+			ErrDisconnectedEdges, start, len(walk), di.E())
+	}
+	return walk, nil
+}
+
+// hierholzer walks from s using every edge once, splicing in detours as it
+// backtracks.  follow(v, &next[v]) gives the next unused edge of v, marking
+// it as used, or false if there are none.
+func hierholzer(v, s int, follow func(int, *int) (EulerEdge, bool)) []EulerEdge {
+	next := make([]int, v)
+	// the bottom of the stack isn't an edge, only where the walk starts
+	stack := []EulerEdge{{To: s}}
+	var walk []EulerEdge
+
+	for len(stack) != 0 {
+		v := stack[len(stack)-1].To
+		if e, ok := follow(v, &next[v]); ok {
+			stack = append(stack, e)
+			continue
+		}
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if len(stack) != 0 {
+			walk = append(walk, e)
+		}
+	}
+
+	for i, j := 0, len(walk)-1; i < j; i, j = i+1, j-1 {
+		walk[i], walk[j] = walk[j], walk[i]
+	}
+	return walk
+}
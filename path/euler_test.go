@@ -0,0 +1,209 @@
+package path
+
+import (
+	"errors"
+	"github.com/aybabtme/graph"
+	"testing"
+)
+
+type eulerEdges []struct{ from, to int }
+
+// checkEulerWalk verifies that walk follows edges of g one after the other,
+// using every edge exactly once.
+func checkEulerWalk(t *testing.T, g graph.Graph, edges eulerEdges, walk []EulerEdge, directed bool) {
+	if len(walk) != len(edges) {
+		t.Fatalf("Walk %v should go through %d edges", walk, len(edges))
+	}
+
+	left := make(map[[2]int]int)
+	key := func(v, w int) [2]int {
+		if !directed && w < v {
+			return [2]int{w, v}
+		}
+		return [2]int{v, w}
+	}
+	for _, e := range edges {
+		left[key(e.from, e.to)]++
+	}
+	seen := make(map[[2]int]bool)
+	for i, e := range walk {
+		if i > 0 && walk[i-1].To != e.From {
+			t.Fatalf("Walk %v jumps from %d to %d", walk, walk[i-1].To, e.From)
+		}
+		if g.Adj(e.From)[e.Index] != e.To {
+			t.Fatalf("Edge %v isn't at its index in the adjacency of %d", e, e.From)
+		}
+		if seen[[2]int{e.From, e.Index}] {
+			t.Fatalf("Walk %v uses edge %v twice", walk, e)
+		}
+		seen[[2]int{e.From, e.Index}] = true
+		k := key(e.From, e.To)
+		if left[k] == 0 {
+			t.Fatalf("Walk %v uses %d-%d more than it exists", walk, e.From, e.To)
+		}
+		left[k]--
+	}
+}
+
+func TestEulerCircuit(t *testing.T) {
+	// Two triangles sharing 2, a self-loop on 4, and a pair of parallel
+	// edges between 0 and 5
+	edges := eulerEdges{
+		{0, 1}, {1, 2}, {2, 0},
+		{2, 3}, {3, 4}, {4, 2},
+		{4, 4},
+		{0, 5}, {5, 0},
+	}
+	g := graph.NewGraph(7)
+	for _, e := range edges {
+		g.AddEdge(e.from, e.to)
+	}
+
+	walk, err := EulerCircuit(g)
+	if err != nil {
+		t.Fatalf("Should have an Euler circuit, %v", err)
+	}
+	checkEulerWalk(t, g, edges, walk, false)
+	if walk[0].From != walk[len(walk)-1].To {
+		t.Errorf("Circuit %v should end where it starts", walk)
+	}
+
+	if _, err := EulerPath(g); err != nil {
+		t.Errorf("A circuit is also a path, %v", err)
+	}
+}
+
+func TestEulerPath(t *testing.T) {
+	// A triangle with a tail: 1 and 3 have odd degree
+	edges := eulerEdges{{0, 1}, {1, 2}, {2, 0}, {1, 3}}
+	g := graph.NewGraph(4)
+	for _, e := range edges {
+		g.AddEdge(e.from, e.to)
+	}
+
+	walk, err := EulerPath(g)
+	if err != nil {
+		t.Fatalf("Should have an Euler path, %v", err)
+	}
+	checkEulerWalk(t, g, edges, walk, false)
+	if walk[0].From != 1 || walk[len(walk)-1].To != 3 {
+		t.Errorf("Path %v should go from 1 to 3", walk)
+	}
+
+	if _, err := EulerCircuit(g); !errors.Is(err, ErrDegreeImbalance) {
+		t.Errorf("Shouldn't have an Euler circuit, got %v", err)
+	}
+}
+
+func TestEulerFailures(t *testing.T) {
+	star := graph.NewGraph(5)
+	for v := 1; v < 5; v++ {
+		star.AddEdge(0, v)
+	}
+	if _, err := EulerPath(star); !errors.Is(err, ErrDegreeImbalance) {
+		t.Errorf("A star shouldn't have an Euler path, got %v", err)
+	}
+
+	twoLoops := graph.NewGraph(6)
+	for _, e := range (eulerEdges{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}}) {
+		twoLoops.AddEdge(e.from, e.to)
+	}
+	if _, err := EulerCircuit(twoLoops); !errors.Is(err, ErrDisconnectedEdges) {
+		t.Errorf("Disconnected triangles shouldn't have an Euler circuit, got %v", err)
+	}
+
+	walk, err := EulerCircuit(graph.NewGraph(3))
+	if err != nil || len(walk) != 0 {
+		t.Errorf("A graph without edges has an empty circuit, got %v, %v", walk, err)
+	}
+}
+
+func TestDirectedEulerCircuit(t *testing.T) {
+	edges := eulerEdges{
+		{0, 1}, {1, 2}, {2, 0},
+		{2, 3}, {3, 2},
+		{1, 1},
+	}
+	di := graph.NewDigraph(5)
+	for _, e := range edges {
+		di.AddEdge(e.from, e.to)
+	}
+
+	walk, err := DirectedEulerCircuit(di)
+	if err != nil {
+		t.Fatalf("Should have an Euler circuit, %v", err)
+	}
+	checkEulerWalk(t, di, edges, walk, true)
+	if walk[0].From != walk[len(walk)-1].To {
+		t.Errorf("Circuit %v should end where it starts", walk)
+	}
+}
+
+func TestEulerCircuitOfParallelEdges(t *testing.T) {
+	// 0 and 1 are joined by four edges and 1 has a self-loop, the walk
+	// must tell them apart to use each once
+	edges := eulerEdges{{0, 1}, {0, 1}, {1, 1}, {1, 0}, {1, 0}}
+	for _, directed := range []bool{false, true} {
+		var g graph.Graph
+		var walk []EulerEdge
+		var err error
+		if directed {
+			di := graph.NewDigraph(2)
+			for _, e := range edges {
+				di.AddEdge(e.from, e.to)
+			}
+			g = di
+			walk, err = DirectedEulerCircuit(di)
+		} else {
+			ug := graph.NewGraph(2)
+			for _, e := range edges {
+				ug.AddEdge(e.from, e.to)
+			}
+			g = ug
+			walk, err = EulerCircuit(ug)
+		}
+		if err != nil {
+			t.Fatalf("Should have an Euler circuit, %v", err)
+		}
+		checkEulerWalk(t, g, edges, walk, directed)
+	}
+}
+
+func TestDirectedEulerPath(t *testing.T) {
+	edges := eulerEdges{{0, 1}, {1, 2}, {2, 0}, {0, 3}}
+	di := graph.NewDigraph(4)
+	for _, e := range edges {
+		di.AddEdge(e.from, e.to)
+	}
+
+	walk, err := DirectedEulerPath(di)
+	if err != nil {
+		t.Fatalf("Should have an Euler path, %v", err)
+	}
+	checkEulerWalk(t, di, edges, walk, true)
+	if walk[0].From != 0 || walk[len(walk)-1].To != 3 {
+		t.Errorf("Path %v should go from 0 to 3", walk)
+	}
+
+	if _, err := DirectedEulerCircuit(di); !errors.Is(err, ErrDegreeImbalance) {
+		t.Errorf("Shouldn't have an Euler circuit, got %v", err)
+	}
+}
+
+func TestDirectedEulerFailures(t *testing.T) {
+	fork := graph.NewDigraph(3)
+	fork.AddEdge(0, 1)
+	fork.AddEdge(0, 2)
+	if _, err := DirectedEulerPath(fork); !errors.Is(err, ErrDegreeImbalance) {
+		t.Errorf("A fork shouldn't have an Euler path, got %v", err)
+	}
+
+	twoLoops := graph.NewDigraph(4)
+	twoLoops.AddEdge(0, 1)
+	twoLoops.AddEdge(1, 0)
+	twoLoops.AddEdge(2, 3)
+	twoLoops.AddEdge(3, 2)
+	if _, err := DirectedEulerCircuit(twoLoops); !errors.Is(err, ErrDisconnectedEdges) {
+		t.Errorf("Disconnected loops shouldn't have an Euler circuit, got %v", err)
+	}
+}
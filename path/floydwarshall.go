@@ -0,0 +1,97 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+	"math"
+)
+
+type floydWarshall struct {
+	distTo [][]float64
+	edgeTo [][]int // edgeTo[v][w] is the vertex before w on the path from v
+	cycle  []int
+}
+
+// BuildFloydWarshall builds the shortest paths between every pair of vertices
+// of weighted digraph wd, using the Floyd-Warshall algorithm.  Edges can have
+// negative weights.  This is O(V^3) and extra space proportional to V^2,
+// which suits dense digraphs.
+func BuildFloydWarshall(wd *graph.EdgeWeightedDigraph) AllPairsShortestPath {
+	n := wd.V()
+	f := floydWarshall{
+		distTo: make([][]float64, n),
+		edgeTo: make([][]int, n),
+	}
+
+	for v := 0; v < n; v++ {
+		f.distTo[v] = make([]float64, n)
+		f.edgeTo[v] = make([]int, n)
+		for w := range f.distTo[v] {
+			f.distTo[v][w] = math.Inf(1)
+			f.edgeTo[v][w] = -1
+		}
+		f.distTo[v][v] = 0.0
+	}
+	for _, e := range wd.Edges() {
+		v, w := e.From(), e.To()
+		if e.Weight() < f.distTo[v][w] {
+			f.distTo[v][w] = e.Weight()
+			f.edgeTo[v][w] = v
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		distK := f.distTo[k]
+		for v := 0; v < n; v++ {
+			distV := f.distTo[v]
+			if math.IsInf(distV[k], 1) {
+				continue
+			}
+			for w := 0; w < n; w++ {
+				if distV[w] > distV[k]+distK[w] {
+					distV[w] = distV[k] + distK[w]
+					f.edgeTo[v][w] = f.edgeTo[k][w]
+				}
+			}
+			if distV[v] < 0 {
+				f.cycle = negativeCycleThrough(wd, v)
+				return f
+			}
+		}
+	}
+
+	return f
+}
+
+func (f floydWarshall) HasPath(v, w int) bool {
+	mustNotHaveNegativeCycle(f)
+	return !math.IsInf(f.distTo[v][w], 1)
+}
+
+func (f floydWarshall) Dist(v, w int) float64 {
+	mustNotHaveNegativeCycle(f)
+	return f.distTo[v][w]
+}
+
+func (f floydWarshall) Path(v, w int) []int {
+	if !f.HasPath(v, w) {
+		return []int{}
+	}
+
+	var path []int
+	for next := w; next != v; next = f.edgeTo[v][next] {
+		path = append(path, next)
+	}
+	path = append(path, v)
+
+	reverse(path)
+
+	return path
+}
+
+func (f floydWarshall) HasNegativeCycle() bool {
+	return len(f.cycle) != 0
+}
+
+func (f floydWarshall) NegativeCycle() []int {
+	return f.cycle
+}
@@ -0,0 +1,96 @@
+package path
+
+import (
+	"fmt"
+	"github.com/aybabtme/graph"
+	"github.com/aybabtme/graph/internal/pq"
+)
+
+// ShortestPathOf is ShortestPath for a graph.WeightDigraphOf, whose
+// distances have the type of the weights of the digraph.  With integer
+// weights, they are exact.
+type ShortestPathOf[W graph.Number] interface {
+	PathFinder
+	// DistTo is the total weight of the shortest path from the source to the
+	// destination, and false if there is no such path
+	DistTo(destination int) (W, bool)
+}
+
+type dijkstraOf[W graph.Number] struct {
+	from    int
+	reached []bool
+	distTo  []W
+	edgeTo  []int
+}
+
+// BuildDijkstraOf is BuildDijkstra for a weighted digraph wd with weights of
+// any type.  The algorithms for an EdgeWeightedDigraph can run on
+// wd.Float64(), but their distances are then rounded like float64s, and
+// those for a Digraph on wd.Digraph().  This is
+// O(E log V) and extra space proportional to V.  It returns an error if wd
+// has an edge of negative weight, or if the distance to a vertex doesn't fit
+// in W.
+func BuildDijkstraOf[W graph.Number](wd *graph.WeightDigraphOf[W], s int) (ShortestPathOf[W], error) {
+	for _, e := range wd.Edges() {
+		if e.Weight() < 0 {
+			return nil, fmt.Errorf("edge %#v has negative weight", &e)
+		}
+	}
+
+	d := dijkstraOf[W]{
+		from:    s,
+		reached: make([]bool, wd.V()),
+		distTo:  make([]W, wd.V()),
+		edgeTo:  make([]int, wd.V()),
+	}
+	d.reached[s] = true
+
+	queue := pq.NewIndexMin(d.distTo)
+	queue.Update(s)
+
+	for queue.Len() != 0 {
+		v := queue.PopMin()
+		for _, e := range wd.Adj(v) {
+			w := e.To()
+			dist := d.distTo[v] + e.Weight()
+			if dist < d.distTo[v] {
+				// weights aren't negative, so only an integer
+				// overflow makes the distance shrink
+				return nil, fmt.Errorf("distance to %d overflows the weight type", w)
+			}
+			if d.reached[w] && d.distTo[w] <= dist {
+				continue
+			}
+			d.reached[w] = true
+			d.distTo[w] = dist
+			d.edgeTo[w] = v
+			queue.Update(w)
+		}
+	}
+
+	return d, nil
+}
+
+func (d dijkstraOf[W]) HasPathTo(to int) bool {
+	return d.reached[to]
+}
+
+func (d dijkstraOf[W]) DistTo(to int) (W, bool) {
+	return d.distTo[to], d.reached[to]
+}
+
+func (d dijkstraOf[W]) PathTo(to int) []int {
+	if !d.HasPathTo(to) {
+		return []int{}
+	}
+
+	var path []int
+	for next := to; next != d.from; next = d.edgeTo[next] {
+		path = append(path, next)
+	}
+	path = append(path, d.from)
+
+	reverse(path)
+
+	return path
+}
@@ -0,0 +1,91 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDijkstraOfMatchesDijkstra(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+	for i := 0; i < 100; i++ {
+		v := 1 + r.Intn(30)
+		wd := graph.NewWeightDigraphOf[int32](v)
+		for e := r.Intn(4 * v); e > 0; e-- {
+			wd.AddEdge(graph.NewDirectedEdgeOf(r.Intn(v), r.Intn(v), int32(r.Intn(100))))
+		}
+		s := r.Intn(v)
+
+		f := wd.Float64()
+		want, err := BuildDijkstra(&f, s)
+		if err != nil {
+			t.Fatalf("Should have built shortest paths, %v", err)
+		}
+		got, err := BuildDijkstraOf(&wd, s)
+		if err != nil {
+			t.Fatalf("Should have built shortest paths, %v", err)
+		}
+
+		for w := 0; w < v; w++ {
+			if got.HasPathTo(w) != want.HasPathTo(w) {
+				t.Fatalf("Path from %d to %d should be %v", s, w, want.HasPathTo(w))
+			}
+			if !want.HasPathTo(w) {
+				if _, ok := got.DistTo(w); ok || len(got.PathTo(w)) != 0 {
+					t.Errorf("Should have no path from %d to %d", s, w)
+				}
+				continue
+			}
+			dist, ok := got.DistTo(w)
+			if !ok || math.Abs(float64(dist)-want.DistTo(w)) > 1e-9 {
+				t.Errorf("Distance to %d, want %f got %d", w, want.DistTo(w), dist)
+			}
+			path := got.PathTo(w)
+			if path[0] != s || path[len(path)-1] != w {
+				t.Errorf("Path to %d should go from %d, got %v", w, s, path)
+			}
+		}
+	}
+}
+
+func TestDijkstraOfRefusesOverflow(t *testing.T) {
+	wd := graph.NewWeightDigraphOf[int8](3)
+	wd.AddEdge(graph.NewDirectedEdgeOf[int8](0, 1, 100))
+	wd.AddEdge(graph.NewDirectedEdgeOf[int8](1, 2, 100))
+
+	if _, err := BuildDijkstraOf(&wd, 0); err == nil {
+		t.Errorf("Should have refused a distance of 200 in an int8")
+	}
+}
+
+func TestDijkstraOfRefusesNegativeWeights(t *testing.T) {
+	wd := graph.NewWeightDigraphOf[int](2)
+	wd.AddEdge(graph.NewDirectedEdgeOf(0, 1, -1))
+
+	if _, err := BuildDijkstraOf(&wd, 0); err == nil {
+		t.Errorf("Should have refused a negative weight")
+	}
+}
+
+func TestDijkstraOfIsExactAbove2To53(t *testing.T) {
+	// 2^53 + 1 has no float64, and neither does the sum of both weights
+	big := int64(1)<<53 + 1
+	wd := graph.NewWeightDigraphOf[int64](3)
+	wd.AddEdge(graph.NewDirectedEdgeOf(0, 1, big))
+	wd.AddEdge(graph.NewDirectedEdgeOf(1, 2, big))
+
+	sp, err := BuildDijkstraOf(&wd, 0)
+	if err != nil {
+		t.Fatalf("Should have built shortest paths, %v", err)
+	}
+	if dist, ok := sp.DistTo(2); !ok || dist != 2*big {
+		t.Errorf("Distance to 2, want %d got %d", 2*big, dist)
+	}
+
+	wd.AddVertex()
+	sp, _ = BuildDijkstraOf(&wd, 0)
+	if _, ok := sp.DistTo(3); ok {
+		t.Errorf("Shouldn't have a distance to unreachable vertex 3")
+	}
+}
@@ -0,0 +1,86 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+	"math"
+)
+
+type johnson struct {
+	height []float64
+	paths  []ShortestPath
+	cycle  []int
+}
+
+// BuildJohnson builds the shortest paths between every pair of vertices of
+// weighted digraph wd, using Johnson's algorithm.  Edges can have negative
+// weights: Bellman-Ford finds a height for every vertex that makes all the
+// edges nonnegative once reweighted, then Dijkstra's algorithm runs from
+// every vertex.  This is O(EV log V) and extra space proportional to V^2,
+// which suits sparse digraphs.
+func BuildJohnson(wd *graph.EdgeWeightedDigraph) AllPairsShortestPath {
+	n := wd.V()
+	j := johnson{
+		height: make([]float64, n),
+		paths:  make([]ShortestPath, n),
+	}
+
+	// a source with an edge to every vertex reaches every negative cycle
+	withSource := graph.NewEdgeWeightedDigraph(n + 1)
+	for _, e := range wd.Edges() {
+		withSource.AddEdge(e)
+	}
+	for v := 0; v < n; v++ {
+		withSource.AddEdge(graph.NewDirectedEdge(n, v, 0.0))
+	}
+	bf := BuildBellmanFord(&withSource, n)
+	if bf.HasNegativeCycle() {
+		j.cycle = bf.NegativeCycle()
+		return j
+	}
+	for v := range j.height {
+		j.height[v] = bf.DistTo(v)
+	}
+
+	reweighted := graph.NewEdgeWeightedDigraph(n)
+	for _, e := range wd.Edges() {
+		v, w := e.From(), e.To()
+		// rounding can leave a tiny negative weight on an edge of a
+		// shortest path, which should weigh 0
+		weight := math.Max(0, e.Weight()+j.height[v]-j.height[w])
+		reweighted.AddEdge(graph.NewDirectedEdge(v, w, weight))
+	}
+	for v := range j.paths {
+		sp, err := BuildDijkstra(&reweighted, v)
+		if err != nil {
+			panic(err)
+		}
+		j.paths[v] = sp
+	}
+
+	return j
+}
+
+func (j johnson) HasPath(v, w int) bool {
+	mustNotHaveNegativeCycle(j)
+	return j.paths[v].HasPathTo(w)
+}
+
+func (j johnson) Dist(v, w int) float64 {
+	if !j.HasPath(v, w) {
+		return math.Inf(1)
+	}
+	return j.paths[v].DistTo(w) - j.height[v] + j.height[w]
+}
+
+func (j johnson) Path(v, w int) []int {
+	mustNotHaveNegativeCycle(j)
+	return j.paths[v].PathTo(w)
+}
+
+func (j johnson) HasNegativeCycle() bool {
+	return len(j.cycle) != 0
+}
+
+func (j johnson) NegativeCycle() []int {
+	return j.cycle
+}
@@ -1,5 +1,9 @@
 package path
 
+import (
+	"github.com/aybabtme/graph"
+)
+
 // PathFinder holds information regarding the paths in a graph from a source
 type PathFinder interface {
 	// HasPathTo tells whether there is a path between the source and the
@@ -17,3 +21,35 @@ func reverse(s []int) []int {
 	}
 	return s
 }
+
+// dfsFrame is a vertex being visited by an iterative depth-first search,
+// along with the index of the next of its adjacent vertices to look at.
+type dfsFrame struct {
+	v    int
+	next int
+}
+
+// visitAll marks every unmarked vertex reachable from s in graph g, in
+// depth-first preorder, calling visit on each of them.
+func visitAll(g graph.Graph, s int, marked []bool, visit func(int)) {
+	marked[s] = true
+	visit(s)
+	stack := []dfsFrame{{v: s}}
+
+	for len(stack) != 0 {
+		f := &stack[len(stack)-1]
+		adj := g.Adj(f.v)
+		if f.next == len(adj) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		w := adj[f.next]
+		f.next++
+
+		if !marked[w] {
+			marked[w] = true
+			visit(w)
+			stack = append(stack, dfsFrame{v: w})
+		}
+	}
+}
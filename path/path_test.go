@@ -88,3 +88,35 @@ func simpleGraphHarness(t *testing.T, pfFactory pfFact) {
 		}
 	}
 }
+
+// deepGraphSize is the vertex count of path graphs used to check that
+// traversals don't recurse once per vertex.
+const deepGraphSize = 10000000
+
+// deepDigraph is the path digraph 0->1->2->...->(v-1)
+func deepDigraph(v int) graph.Digraph {
+	di := graph.NewDigraph(v)
+	for w := 1; w < v; w++ {
+		di.AddEdge(w-1, w)
+	}
+	return di
+}
+
+func TestDeepSearch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Not running test on deep graphs")
+	}
+	di := deepDigraph(deepGraphSize)
+
+	for _, pf := range pathFinders {
+		p := pf(di, 0)
+		last := deepGraphSize - 1
+		if !p.HasPathTo(last) {
+			t.Fatalf("Should have a path to %d", last)
+		}
+		if len(p.PathTo(last)) != deepGraphSize {
+			t.Errorf("Path to %d should have %d vertices but had %d",
+				last, deepGraphSize, len(p.PathTo(last)))
+		}
+	}
+}
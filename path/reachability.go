@@ -0,0 +1,171 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+	"sort"
+)
+
+// Reachability answers queries of the form "Is there a directed path from
+// vertex v to vertex w?", as TransitiveClosure does.
+type Reachability interface {
+	// Reachable tells if vertex w is reachable from vertex v
+	Reachable(v, w int) bool
+}
+
+// bitset is a set of small non-negative integers.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) add(i int) {
+	b[i/64] |= 1 << uint(i%64)
+}
+
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<uint(i%64)) != 0
+}
+
+// union adds every integer of other to b.
+func (b bitset) union(other bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+type bitsetClosure struct {
+	scc   SCC
+	reach []bitset
+}
+
+// BuildBitsetClosure builds a model of all-pair reachable vertices of
+// digraph di.  Vertices of a strongly connected component reach the same
+// vertices, so only the condensation of di is kept, with the set of
+// components reachable from every component held in a bitset.  This is
+// O(C(C + E)/64) for C components, and extra space proportional to V + C^2/64
+// instead of the V^2 of BuildTransitiveClosure.
+func BuildBitsetClosure(di graph.Digraph) Reachability {
+	scc := BuildTarjanSCC(di)
+	dag, _ := BuildCondensation(di, scc)
+
+	b := bitsetClosure{
+		scc:   scc,
+		reach: make([]bitset, dag.V()),
+	}
+
+	// Successors come after their predecessors in topological order, so
+	// going backward finds every successor done.
+	order := dag.Sort()
+	for i := len(order) - 1; i >= 0; i-- {
+		c := order[i]
+		b.reach[c] = newBitset(dag.V())
+		b.reach[c].add(c)
+		for _, d := range dag.Adj(c) {
+			b.reach[c].union(b.reach[d])
+		}
+	}
+	return b
+}
+
+func (b bitsetClosure) Reachable(v, w int) bool {
+	return b.reach[b.scc.ID(v)].has(b.scc.ID(w))
+}
+
+// interval is the range of postorder numbers [lo, hi].
+type interval struct {
+	lo, hi int
+}
+
+type intervalClosure struct {
+	scc       SCC
+	post      []int
+	intervals [][]interval
+}
+
+// BuildIntervalClosure builds a model of all-pair reachable vertices of
+// digraph di, using the interval labeling of Agrawal, Borgida and Jagadish
+// on the condensation of di.  Components are numbered in postorder of a
+// spanning forest of the condensation, so that a tree is an interval of
+// numbers, and every component is labeled with the fewest intervals covering
+// the components it reaches.  Labels are small when the condensation is
+// close to a forest, and up to C/2 intervals otherwise.  Queries are
+// O(log C).
+func BuildIntervalClosure(di graph.Digraph) Reachability {
+	scc := BuildTarjanSCC(di)
+	dag, _ := BuildCondensation(di, scc)
+	order := dag.Sort()
+
+	ic := intervalClosure{
+		scc:       scc,
+		post:      make([]int, dag.V()),
+		intervals: make([][]interval, dag.V()),
+	}
+
+	// lowest[c] is the lowest postorder number in the tree of c
+	lowest := make([]int, dag.V())
+	marked := make([]bool, dag.V())
+	count := 0
+	for _, s := range order {
+		if marked[s] {
+			continue
+		}
+		marked[s] = true
+		lowest[s] = count
+		stack := []dfsFrame{{v: s}}
+		for len(stack) != 0 {
+			f := &stack[len(stack)-1]
+			adj := dag.Adj(f.v)
+			if f.next < len(adj) {
+				w := adj[f.next]
+				f.next++
+				if !marked[w] {
+					marked[w] = true
+					lowest[w] = count
+					stack = append(stack, dfsFrame{v: w})
+				}
+				continue
+			}
+			ic.post[f.v] = count
+			count++
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		c := order[i]
+		labels := []interval{{lo: lowest[c], hi: ic.post[c]}}
+		for _, d := range dag.Adj(c) {
+			labels = append(labels, ic.intervals[d]...)
+		}
+		ic.intervals[c] = mergeIntervals(labels)
+	}
+	return ic
+}
+
+// mergeIntervals gives the fewest intervals covering the same numbers as
+// intervals, in increasing order.
+func mergeIntervals(intervals []interval) []interval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].lo < intervals[j].lo
+	})
+	merged := intervals[:1]
+	for _, next := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if next.lo > last.hi+1 {
+			merged = append(merged, next)
+		} else if next.hi > last.hi {
+			last.hi = next.hi
+		}
+	}
+	return append([]interval(nil), merged...)
+}
+
+func (ic intervalClosure) Reachable(v, w int) bool {
+	labels := ic.intervals[ic.scc.ID(v)]
+	p := ic.post[ic.scc.ID(w)]
+	// the last interval starting at or before p is the only one that can
+	// hold it
+	i := sort.Search(len(labels), func(i int) bool { return labels[i].lo > p }) - 1
+	return i >= 0 && p <= labels[i].hi
+}
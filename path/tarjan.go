@@ -0,0 +1,88 @@
+package path
+
+import (
+	"github.com/aybabtme/graph"
+)
+
+// BuildTarjanSCC builds a Strongly Connected Component representation of
+// digraph di using Tarjan's algorithm.  Unlike BuildSCC, it does a single
+// depth-first pass and doesn't need the reverse of di, using extra space
+// proportional to V instead of E + V.
+func BuildTarjanSCC(di graph.Digraph) SCC {
+	scc := strongComp{
+		id:    make([]int, di.V()),
+		count: 0,
+	}
+
+	pre := make([]int, di.V())
+	low := make([]int, di.V())
+	onStack := make([]bool, di.V())
+	for v := range pre {
+		pre[v] = -1
+	}
+
+	var (
+		preCount int
+		stack    []int
+		frames   []dfsFrame
+	)
+
+	discover := func(v int) {
+		pre[v] = preCount
+		low[v] = preCount
+		preCount++
+		stack = append(stack, v)
+		onStack[v] = true
+		frames = append(frames, dfsFrame{v: v})
+	}
+
+	for s := 0; s < di.V(); s++ {
+		if pre[s] != -1 {
+			continue
+		}
+		discover(s)
+
+		for len(frames) != 0 {
+			f := &frames[len(frames)-1]
+			v := f.v
+			adj := di.Adj(v)
+
+			if f.next < len(adj) {
+				w := adj[f.next]
+				f.next++
+				if pre[w] == -1 {
+					discover(w)
+				} else if onStack[w] && pre[w] < low[v] {
+					low[v] = pre[w]
+				}
+				continue
+			}
+
+			frames = frames[:len(frames)-1]
+			if len(frames) != 0 {
+				parent := frames[len(frames)-1].v
+				if low[v] < low[parent] {
+					low[parent] = low[v]
+				}
+			}
+
+			if low[v] != pre[v] {
+				continue
+			}
+			// v is the root of a component, made of everything above it
+			// on the stack
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc.id[w] = scc.count
+				if w == v {
+					break
+				}
+			}
+			scc.count++
+		}
+	}
+
+	return scc
+}
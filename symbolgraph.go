@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// symbolTable maps vertex names to their index in a graph, and back.
+type symbolTable[K comparable] struct {
+	st   map[K]int
+	keys []K
+}
+
+func newSymbolTable[K comparable]() *symbolTable[K] {
+	return &symbolTable[K]{st: make(map[K]int)}
+}
+
+// Contains tells if there is a vertex named name.
+func (s *symbolTable[K]) Contains(name K) bool {
+	_, ok := s.st[name]
+	return ok
+}
+
+// Index is the vertex named name, or -1 if there is none.
+func (s *symbolTable[K]) Index(name K) int {
+	v, ok := s.st[name]
+	if !ok {
+		return -1
+	}
+	return v
+}
+
+// Name is the name of vertex v.
+func (s *symbolTable[K]) Name(v int) K {
+	return s.keys[v]
+}
+
+func (s *symbolTable[K]) add(name K) int {
+	v, ok := s.st[name]
+	if !ok {
+		v = len(s.keys)
+		s.st[name] = v
+		s.keys = append(s.keys, name)
+	}
+	return v
+}
+
+// readSymbols reads lines of delim separated names from input, returning
+// the table of all names seen and the edges going from the first name of
+// each line to every other name on that line.
+func readSymbols(input io.Reader, delim string) (*symbolTable[string], [][2]int, error) {
+	st := newSymbolTable[string]()
+	var edges [][2]int
+
+	scan := bufio.NewScanner(input)
+	for scan.Scan() {
+		if strings.TrimSpace(scan.Text()) == "" {
+			continue
+		}
+		names := strings.Split(scan.Text(), delim)
+		v := st.add(names[0])
+		for _, name := range names[1:] {
+			edges = append(edges, [2]int{v, st.add(name)})
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed reading symbols, %v", err)
+	}
+	return st, edges, nil
+}
+
+// SymbolGraph is an undirected graph whose vertices are named by strings.
+type SymbolGraph struct {
+	*symbolTable[string]
+	g Ungraph
+}
+
+// ReadSymbolGraph constructs an undirected symbol graph from the io.Reader
+// expecting to find lines formed such as:
+//   a/b/c
+//   d/e
+//   ...
+// where `/` is delim, and each line connects its first name `a` to each of
+// the other names `b` and `c` that follow it.
+func ReadSymbolGraph(input io.Reader, delim string) (SymbolGraph, error) {
+	st, edges, err := readSymbols(input, delim)
+	if err != nil {
+		return SymbolGraph{}, err
+	}
+
+	g := NewGraph(len(st.keys))
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1])
+	}
+	return SymbolGraph{symbolTable: st, g: g}, nil
+}
+
+// Graph is the underlying graph, indexed by the vertices of this symbol
+// graph.
+func (s SymbolGraph) Graph() Ungraph {
+	return s.g
+}
+
+// SymbolDigraph is a directed graph whose vertices are named by strings.
+type SymbolDigraph struct {
+	*symbolTable[string]
+	di Digraph
+}
+
+// ReadSymbolDigraph constructs a directed symbol graph from the io.Reader
+// expecting to find lines formed such as:
+//   a/b/c
+//   d/e
+//   ...
+// where `/` is delim, and each line has edges from its first name `a` to
+// each of the other names `b` and `c` that follow it.
+func ReadSymbolDigraph(input io.Reader, delim string) (SymbolDigraph, error) {
+	st, edges, err := readSymbols(input, delim)
+	if err != nil {
+		return SymbolDigraph{}, err
+	}
+
+	di := NewDigraph(len(st.keys))
+	for _, e := range edges {
+		di.AddEdge(e[0], e[1])
+	}
+	return SymbolDigraph{symbolTable: st, di: di}, nil
+}
+
+// Digraph is the underlying digraph, indexed by the vertices of this symbol
+// digraph.
+func (s SymbolDigraph) Digraph() Digraph {
+	return s.di
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+// The routes.txt file from Algorithms 4th Ed.
+const routes = `JFK MCO
+ORD DEN
+ORD HOU
+DFW PHX
+JFK ATL
+ORD DFW
+ORD PHX
+ATL HOU
+DEN PHX
+PHX LAX
+JFK ORD
+DEN LAS
+DFW HOU
+ORD ATL
+LAS LAX
+ATL MCO
+HOU MCO
+LAS PHX
+`
+
+func TestSymbolGraphFromReader(t *testing.T) {
+	sg, err := ReadSymbolGraph(strings.NewReader(routes), " ")
+	if err != nil {
+		t.Fatalf("Couldn't read symbol graph, %v", err)
+	}
+
+	g := sg.Graph()
+	if g.V() != 10 {
+		t.Errorf("Vertex count, want %d got %d", 10, g.V())
+	}
+	if g.E() != 18 {
+		t.Errorf("Edge count, want %d got %d", 18, g.E())
+	}
+
+	want := map[string]bool{"ORD": true, "ATL": true, "MCO": true}
+	jfk := sg.Index("JFK")
+	if len(g.Adj(jfk)) != len(want) {
+		t.Errorf("JFK should have %d neighbors, had %d", len(want), len(g.Adj(jfk)))
+	}
+	for _, v := range g.Adj(jfk) {
+		if !want[sg.Name(v)] {
+			t.Errorf("JFK should not be adjacent to %s", sg.Name(v))
+		}
+	}
+
+	for v := 0; v < g.V(); v++ {
+		if sg.Index(sg.Name(v)) != v {
+			t.Errorf("Index and Name should be inverses, %d gave %q",
+				v, sg.Name(v))
+		}
+	}
+}
+
+func TestSymbolGraphDoesNotContain(t *testing.T) {
+	sg, err := ReadSymbolGraph(strings.NewReader(routes), " ")
+	if err != nil {
+		t.Fatalf("Couldn't read symbol graph, %v", err)
+	}
+
+	if sg.Contains("YUL") {
+		t.Errorf("Should not contain YUL")
+	}
+	if sg.Index("YUL") != -1 {
+		t.Errorf("Index of YUL should be -1, was %d", sg.Index("YUL"))
+	}
+	if !sg.Contains("LAX") {
+		t.Errorf("Should contain LAX")
+	}
+}
+
+func TestSymbolDigraphFromReader(t *testing.T) {
+	input := "a/b/c\n\nb/c\nc/a\nd\n"
+	sd, err := ReadSymbolDigraph(strings.NewReader(input), "/")
+	if err != nil {
+		t.Fatalf("Couldn't read symbol digraph, %v", err)
+	}
+
+	di := sd.Digraph()
+	if di.V() != 4 {
+		t.Errorf("Vertex count, want %d got %d", 4, di.V())
+	}
+	if di.E() != 4 {
+		t.Errorf("Edge count, want %d got %d", 4, di.E())
+	}
+	if len(di.Adj(sd.Index("a"))) != 2 {
+		t.Errorf("a should point to b and c, was %v", di.Adj(sd.Index("a")))
+	}
+	if len(di.Adj(sd.Index("d"))) != 0 {
+		t.Errorf("d should point nowhere, was %v", di.Adj(sd.Index("d")))
+	}
+	if sd.Name(di.Adj(sd.Index("c"))[0]) != "a" {
+		t.Errorf("c should point to a")
+	}
+}
@@ -6,28 +6,78 @@ import (
 )
 
 // Ungraph is an adjacency list undirected graph. It consumes 2E + V
-// spaces.
+// spaces. Copies of an Ungraph share its vertices, edges and edge count.
 type Ungraph struct {
-	v   int
 	e   *int
-	adj [][]int
+	adj *[][]int
 }
 
 // AddEdge adds an edge from v to w. This is O(1).
 func (a Ungraph) AddEdge(v, w int) {
-	a.adj[v] = append(a.adj[v], w)
-	a.adj[w] = append(a.adj[w], v)
+	(*a.adj)[v] = append((*a.adj)[v], w)
+	(*a.adj)[w] = append((*a.adj)[w], v)
 	(*a.e)++
 }
 
+// HasEdge tells if there is an edge between v and w. This is O(degree(v)).
+func (a Ungraph) HasEdge(v, w int) bool {
+	return indexOf((*a.adj)[v], w) != -1
+}
+
+// RemoveEdge removes one edge between v and w, telling if there was one.
+// Copies of this graph share its edges and edge count, and see the removal.
+// Slices previously returned by Adj(v) and Adj(w) may change. This is
+// O(degree(v) + degree(w)).
+func (a Ungraph) RemoveEdge(v, w int) bool {
+	var ok bool
+	if (*a.adj)[v], ok = removeLast((*a.adj)[v], w); !ok {
+		return false
+	}
+	// a self-loop appears twice in the adjacency of its vertex
+	(*a.adj)[w], _ = removeLast((*a.adj)[w], v)
+	(*a.e)--
+	return true
+}
+
+// RemoveVertex removes every edge incident to v. Vertices are named by
+// their index, so v itself stays in the graph, isolated, which keeps the
+// names of the other vertices. Copies of this graph see the removal. The
+// adjacency lists of the neighbors of v are filtered in place, so slices
+// previously returned by Adj for them may change. This is O(degree(v) + the
+// sum of the degrees of its neighbors).
+func (a Ungraph) RemoveVertex(v int) {
+	loops := 0
+	for _, w := range (*a.adj)[v] {
+		if w == v {
+			loops++
+			continue
+		}
+		(*a.adj)[w], _ = removeLast((*a.adj)[w], v)
+		(*a.e)--
+	}
+	*a.e -= loops / 2
+	(*a.adj)[v] = nil
+}
+
+// AddVertex adds an isolated vertex to the graph, returning it. Copies of
+// this graph share its vertices, and see the new one. This is O(1)
+// amortized.
+func (a Ungraph) AddVertex() int {
+	*a.adj = append(*a.adj, nil)
+	return len(*a.adj) - 1
+}
+
 // Adj is a slice of vertices adjacent to v. This is O(E).
 func (a Ungraph) Adj(v int) []int {
-	return a.adj[v]
+	return (*a.adj)[v]
 }
 
 // V is the number of vertices. This is O(1).
 func (a Ungraph) V() int {
-	return a.v
+	if a.adj == nil {
+		return 0
+	}
+	return len(*a.adj)
 }
 
 // E is the number of edges. This is O(1).
@@ -43,10 +93,10 @@ func (a Ungraph) GoString() string {
 // NewGraph returns a Graph of size v implemented with an adjacency vertex
 // list.
 func NewGraph(v int) Ungraph {
+	adj := make([][]int, v)
 	return Ungraph{
-		v:   v,
 		e:   new(int),
-		adj: make([][]int, v),
+		adj: &adj,
 	}
 }
 
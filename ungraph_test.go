@@ -141,3 +141,102 @@ func TestUngraphFromFile(t *testing.T) {
 		}
 	}
 }
+
+func TestUngraphRemoveEdge(t *testing.T) {
+	g := NewGraph(4)
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 3)
+
+	if !g.HasEdge(1, 0) || !g.HasEdge(3, 3) || g.HasEdge(0, 2) {
+		t.Fatalf("HasEdge doesn't match edges added in\n%s", g.GoString())
+	}
+
+	if !g.RemoveEdge(1, 0) {
+		t.Fatalf("Should have removed an edge between 1 and 0")
+	}
+	if !g.HasEdge(0, 1) || g.E() != 3 {
+		t.Errorf("Should have kept the parallel edge between 0 and 1, E=%d", g.E())
+	}
+
+	if !g.RemoveEdge(3, 3) {
+		t.Fatalf("Should have removed the self-loop on 3")
+	}
+	if g.HasEdge(3, 3) || len(g.Adj(3)) != 0 {
+		t.Errorf("Self-loop should be gone from both ends, adj=%v", g.Adj(3))
+	}
+
+	if g.RemoveEdge(0, 2) {
+		t.Errorf("Should not have removed an edge that doesn't exist")
+	}
+	if g.E() != 2 {
+		t.Errorf("Expected 2 edges but was %d", g.E())
+	}
+}
+
+func TestUngraphRemoveVertex(t *testing.T) {
+	g := NewGraph(4)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 1)
+	g.AddEdge(2, 3)
+
+	g.RemoveVertex(1)
+
+	if g.V() != 4 {
+		t.Errorf("Should still have 4 vertices, had %d", g.V())
+	}
+	if g.E() != 1 {
+		t.Errorf("Should only have the edge 2-3 left, E=%d in\n%s", g.E(), g.GoString())
+	}
+	for v := 0; v < g.V(); v++ {
+		if g.HasEdge(v, 1) || g.HasEdge(1, v) {
+			t.Errorf("Should have no edge between %d and 1", v)
+		}
+	}
+	if !g.HasEdge(3, 2) {
+		t.Errorf("Should have kept the edge 2-3")
+	}
+}
+
+func TestUngraphCopiesShareEdgeCount(t *testing.T) {
+	g := NewGraph(3)
+	g.AddEdge(0, 1)
+
+	cp := g
+	cp.AddEdge(1, 2)
+	if g.E() != 2 || !g.HasEdge(2, 1) {
+		t.Errorf("Original should see the edge added to the copy, E=%d", g.E())
+	}
+
+	g.RemoveEdge(0, 1)
+	if cp.E() != 1 || cp.HasEdge(1, 0) {
+		t.Errorf("Copy should see the edge removed from the original, E=%d", cp.E())
+	}
+
+	v := g.AddVertex()
+	if v != 3 || g.V() != 4 || len(g.Adj(v)) != 0 {
+		t.Fatalf("Should have added isolated vertex 3, got %d with V=%d", v, g.V())
+	}
+	if cp.V() != 4 {
+		t.Fatalf("Copy made before should see the new vertex, V=%d", cp.V())
+	}
+	cp.AddEdge(v, 2)
+	if !g.HasEdge(2, v) || g.E() != 2 {
+		t.Errorf("Original should see the edge to the new vertex added to the copy, E=%d", g.E())
+	}
+	g.RemoveEdge(2, v)
+
+	g.AddEdge(v, 0)
+	if g.E() != 2 || !g.HasEdge(0, v) || !g.HasEdge(2, 1) {
+		t.Errorf("Should have kept its edges and connected the new vertex, E=%d", g.E())
+	}
+
+	cp = g
+	cp.RemoveVertex(v)
+	if g.E() != 1 || g.HasEdge(0, v) {
+		t.Errorf("Original should see the new vertex removed from a copy, E=%d", g.E())
+	}
+}
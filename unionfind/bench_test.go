@@ -0,0 +1,63 @@
+package unionfind
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func Benchmark_UF_1e6(b *testing.B)           { benchmarkUnions(unionFinds["UF"], 1e6, b) }
+func Benchmark_UF_1e8(b *testing.B)           { benchmarkUnions(unionFinds["UF"], 1e8, b) }
+func Benchmark_CompressedUF_1e6(b *testing.B) { benchmarkUnions(unionFinds["CompressedUF"], 1e6, b) }
+func Benchmark_CompressedUF_1e8(b *testing.B) { benchmarkUnions(unionFinds["CompressedUF"], 1e8, b) }
+func Benchmark_RollbackUF_1e6(b *testing.B)   { benchmarkUnions(unionFinds["RollbackUF"], 1e6, b) }
+func Benchmark_RollbackUF_1e8(b *testing.B)   { benchmarkUnions(unionFinds["RollbackUF"], 1e8, b) }
+
+// benchmarkUnions connects random pairs of entries, picked among unions/10 of
+// them so that most of the unions land on already connected entries.
+func benchmarkUnions(build func(int) unionFind, unions int, b *testing.B) {
+	n := unions / 10
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		uf := build(n)
+		// xorshift, cheaper than math/rand next to a union
+		x := uint64(88172645463325252)
+		for u := 0; u < unions; u++ {
+			x ^= x << 13
+			x ^= x >> 7
+			x ^= x << 17
+			uf.Union(int(x%uint64(n)), int((x>>32)%uint64(n)))
+		}
+	}
+}
+
+func Benchmark_ConcurrentUF_1e6(b *testing.B) { benchmarkConcurrentUnions(1e6, b) }
+func Benchmark_ConcurrentUF_1e8(b *testing.B) { benchmarkConcurrentUnions(1e8, b) }
+
+// benchmarkConcurrentUnions is benchmarkUnions, with the unions spread
+// across GOMAXPROCS goroutines sharing a ConcurrentUF.
+func benchmarkConcurrentUnions(unions int, b *testing.B) {
+	n := unions / 10
+	workers := runtime.GOMAXPROCS(0)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		uf := BuildConcurrentUF(n)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(seed uint64) {
+				defer wg.Done()
+				x := seed
+				for u := 0; u < unions/workers; u++ {
+					x ^= x << 13
+					x ^= x >> 7
+					x ^= x << 17
+					uf.Union(int(x%uint64(n)), int((x>>32)%uint64(n)))
+				}
+			}(88172645463325252 + uint64(w))
+		}
+		wg.Wait()
+	}
+}
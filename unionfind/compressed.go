@@ -0,0 +1,83 @@
+package unionfind
+
+// CompressedUF is a weighted quick union find that also compresses the paths
+// it walks in Find, so that every operation takes nearly constant amortized
+// time.
+type CompressedUF struct {
+	id    []int
+	sz    []int
+	next  ring
+	count int
+}
+
+// BuildCompressedUF initialize n sites with integer names (0 to N-1)
+func BuildCompressedUF(n int) CompressedUF {
+	uf := CompressedUF{
+		id:    make([]int, n),
+		sz:    make([]int, n),
+		next:  newRing(n),
+		count: n,
+	}
+
+	for i := 0; i < n; i++ {
+		uf.id[i] = i
+		uf.sz[i] = 1
+	}
+	return uf
+}
+
+// Union adds a connection between p and q
+func (u *CompressedUF) Union(p, q int) {
+	i := u.Find(p)
+	j := u.Find(q)
+
+	if i == j {
+		return
+	}
+
+	if u.sz[i] < u.sz[j] {
+		u.id[i] = j
+		u.sz[j] += u.sz[i]
+	} else {
+		u.id[j] = i
+		u.sz[i] += u.sz[j]
+	}
+	u.next.splice(i, j)
+	u.count--
+}
+
+// Find tells the component identifier for p (0 to N-1).  Every entry on the
+// way from p to its root is made to point to its grandparent, halving the
+// length of the path.
+func (u *CompressedUF) Find(p int) int {
+	for p != u.id[p] {
+		u.id[p] = u.id[u.id[p]]
+		p = u.id[p]
+	}
+	return p
+}
+
+// Connected is true if p and q are in the same component
+func (u *CompressedUF) Connected(p, q int) bool { return u.Find(p) == u.Find(q) }
+
+// Count tells the number of components
+func (u *CompressedUF) Count() int { return u.count }
+
+// Members lists the entries in the same component as p, in no particular
+// order.  This takes time proportional to the size of the component.
+func (u *CompressedUF) Members(p int) []int { return u.next.members(p) }
+
+// Components lists the entries of every component
+func (u *CompressedUF) Components() [][]int {
+	return components(len(u.id), u.count, u.Find)
+}
+
+// add adds a site in a component of its own, returning its name
+func (u *CompressedUF) add() int {
+	p := len(u.id)
+	u.id = append(u.id, p)
+	u.sz = append(u.sz, 1)
+	u.next = append(u.next, p)
+	u.count++
+	return p
+}
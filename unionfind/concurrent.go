@@ -0,0 +1,108 @@
+package unionfind
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// ConcurrentUF is a union find that is safe for concurrent use by many
+// goroutines, without locks.  Every entry packs its rank and its parent in a
+// single word that is only ever changed by compare-and-swap, after Anderson
+// and Woll's wait-free union find.  It holds at most 2^32 entries.
+type ConcurrentUF struct {
+	data  []atomic.Uint64
+	count int64
+}
+
+// BuildConcurrentUF initialize n sites with integer names (0 to N-1)
+func BuildConcurrentUF(n int) *ConcurrentUF {
+	if uint64(n) > math.MaxUint32+1 {
+		panic("unionfind: too many sites for a ConcurrentUF")
+	}
+	uf := &ConcurrentUF{
+		data:  make([]atomic.Uint64, n),
+		count: int64(n),
+	}
+	for i := range uf.data {
+		uf.data[i].Store(pack(0, i))
+	}
+	return uf
+}
+
+func pack(rank, parent int) uint64 { return uint64(rank)<<32 | uint64(parent) }
+
+func rankOf(value uint64) int { return int(value >> 32) }
+
+func parentOf(value uint64) int { return int(value & math.MaxUint32) }
+
+func (u *ConcurrentUF) parent(p int) int { return parentOf(u.data[p].Load()) }
+
+// Union adds a connection between p and q
+func (u *ConcurrentUF) Union(p, q int) { u.Merge(p, q) }
+
+// Merge adds a connection between p and q, telling if they were in different
+// components.  Unlike calling Connected then Union, this is atomic: of many
+// goroutines merging the same two components, only one is told it did.
+func (u *ConcurrentUF) Merge(p, q int) bool {
+	for {
+		i, j := u.Find(p), u.Find(q)
+		if i == j {
+			return false
+		}
+
+		// link the root of smaller rank under the other, breaking ties by
+		// index so that concurrent unions agree on the direction
+		ri, rj := rankOf(u.data[i].Load()), rankOf(u.data[j].Load())
+		if ri > rj || (ri == rj && i > j) {
+			i, j = j, i
+			ri, rj = rj, ri
+		}
+		if !u.data[i].CompareAndSwap(pack(ri, i), pack(ri, j)) {
+			// i is no longer a root of rank ri, try again
+			continue
+		}
+		if ri == rj {
+			// failing is fine, the rank is only a hint for balancing
+			u.data[j].CompareAndSwap(pack(rj, j), pack(rj+1, j))
+		}
+		atomic.AddInt64(&u.count, -1)
+		return true
+	}
+}
+
+// Find tells the component identifier for p (0 to N-1).  Every entry on the
+// way from p to its root is made to point to its grandparent, unless another
+// goroutine changed it in the meantime.
+func (u *ConcurrentUF) Find(p int) int {
+	for {
+		value := u.data[p].Load()
+		parent := parentOf(value)
+		if parent == p {
+			return p
+		}
+		grandparent := u.parent(parent)
+		if grandparent != parent {
+			u.data[p].CompareAndSwap(value, pack(rankOf(value), grandparent))
+		}
+		p = grandparent
+	}
+}
+
+// Connected is true if p and q are in the same component.  When unions
+// happen concurrently, this tells if p and q were connected at some point
+// during the call.
+func (u *ConcurrentUF) Connected(p, q int) bool {
+	for {
+		i, j := u.Find(p), u.Find(q)
+		if i == j {
+			return true
+		}
+		// i and j were both roots when j was found only if i still is one
+		if u.parent(i) == i {
+			return false
+		}
+	}
+}
+
+// Count tells the number of components
+func (u *ConcurrentUF) Count() int { return int(atomic.LoadInt64(&u.count)) }
@@ -0,0 +1,88 @@
+package unionfind
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConcurrentUFMatchesKnownModel(t *testing.T) {
+	uf := BuildConcurrentUF(10)
+	for _, pair := range tinyUF {
+		uf.Union(pair.from, pair.to)
+		if !uf.Connected(pair.from, pair.to) {
+			t.Errorf("Union (%d,%d) was not recorded", pair.from, pair.to)
+		}
+	}
+	if uf.Count() != expectedCount {
+		t.Errorf("Should have counted %d components, but was %d",
+			expectedCount, uf.Count())
+	}
+	if uf.Merge(3, 9) {
+		t.Errorf("3 and 9 were already connected")
+	}
+}
+
+func TestConcurrentUFUnderLoad(t *testing.T) {
+	n, unions, workers := 10000, 200000, 16
+	if testing.Short() {
+		n, unions = 1000, 20000
+	}
+
+	r := rand.New(rand.NewSource(18))
+	pairs := make([][2]int, unions)
+	for i := range pairs {
+		pairs[i] = [2]int{r.Intn(n), r.Intn(n)}
+	}
+
+	want := BuildUF(n)
+	for _, pair := range pairs {
+		want.Union(pair[0], pair[1])
+	}
+
+	uf := BuildConcurrentUF(n)
+	var (
+		merged int64
+		wg     sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// every worker does every union, starting at a different place,
+			// so that many of them race on the same components
+			for i := range pairs {
+				pair := pairs[(i+w*len(pairs)/workers)%len(pairs)]
+				if uf.Merge(pair[0], pair[1]) {
+					atomic.AddInt64(&merged, 1)
+				}
+				if !uf.Connected(pair[0], pair[1]) {
+					t.Errorf("Union (%d,%d) was not recorded", pair[0], pair[1])
+					return
+				}
+				_ = uf.Count()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if uf.Count() != want.Count() {
+		t.Errorf("Should have counted %d components, but was %d", want.Count(), uf.Count())
+	}
+	if int(merged) != n-want.Count() {
+		t.Errorf("Should have merged %d times, but was %d", n-want.Count(), merged)
+	}
+	roots := make(map[int]int)
+	for p := 0; p < n; p++ {
+		root, ok := roots[want.Find(p)]
+		if !ok {
+			roots[want.Find(p)] = uf.Find(p)
+		} else if root != uf.Find(p) {
+			t.Fatalf("%d should be in the same component as %d", p, root)
+		}
+	}
+	if len(roots) != want.Count() {
+		t.Errorf("Should have %d distinct roots, but had %d", want.Count(), len(roots))
+	}
+}
@@ -0,0 +1,84 @@
+package unionfind
+
+// KeyedUF is a union find over entries named by keys of type K, instead of
+// integers.  Entries are added by Add and Union, the first time they are
+// seen.  Queries about keys never seen don't add them.
+type KeyedUF[K comparable] struct {
+	uf    CompressedUF
+	index map[K]int
+	keys  []K
+}
+
+// BuildKeyedUF initialize an empty union find over keys of type K
+func BuildKeyedUF[K comparable]() *KeyedUF[K] {
+	return &KeyedUF[K]{
+		uf:    BuildCompressedUF(0),
+		index: make(map[K]int),
+	}
+}
+
+func (u *KeyedUF[K]) site(p K) int {
+	i, ok := u.index[p]
+	if !ok {
+		i = u.uf.add()
+		u.index[p] = i
+		u.keys = append(u.keys, p)
+	}
+	return i
+}
+
+// Add adds p in a component of its own, if it isn't there already
+func (u *KeyedUF[K]) Add(p K) { u.site(p) }
+
+// Union adds a connection between p and q
+func (u *KeyedUF[K]) Union(p, q K) { u.uf.Union(u.site(p), u.site(q)) }
+
+// Find tells the key identifying the component of p, and false if p was
+// never seen
+func (u *KeyedUF[K]) Find(p K) (K, bool) {
+	i, ok := u.index[p]
+	if !ok {
+		var none K
+		return none, false
+	}
+	return u.keys[u.uf.Find(i)], true
+}
+
+// Connected is true if p and q are in the same component, and false if
+// either was never seen
+func (u *KeyedUF[K]) Connected(p, q K) bool {
+	i, seenP := u.index[p]
+	j, seenQ := u.index[q]
+	return seenP && seenQ && u.uf.Connected(i, j)
+}
+
+// Count tells the number of components
+func (u *KeyedUF[K]) Count() int { return u.uf.Count() }
+
+// Members lists the keys in the same component as p, in no particular
+// order, or nil if p was never seen
+func (u *KeyedUF[K]) Members(p K) []K {
+	i, ok := u.index[p]
+	if !ok {
+		return nil
+	}
+	return u.named(u.uf.Members(i))
+}
+
+// Components lists the keys of every component, in the order they were
+// first seen
+func (u *KeyedUF[K]) Components() [][]K {
+	var comps [][]K
+	for _, c := range u.uf.Components() {
+		comps = append(comps, u.named(c))
+	}
+	return comps
+}
+
+func (u *KeyedUF[K]) named(sites []int) []K {
+	keys := make([]K, len(sites))
+	for i, p := range sites {
+		keys[i] = u.keys[p]
+	}
+	return keys
+}
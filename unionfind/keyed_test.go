@@ -0,0 +1,61 @@
+package unionfind
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestKeyedUF(t *testing.T) {
+	uf := BuildKeyedUF[string]()
+	uf.Union("a", "b")
+	uf.Union("c", "d")
+	uf.Union("b", "d")
+	uf.Add("e")
+
+	if uf.Count() != 2 {
+		t.Errorf("Should have counted 2 components, but was %d", uf.Count())
+	}
+	if !uf.Connected("a", "c") || uf.Connected("a", "e") {
+		t.Errorf("Only a, b, c and d should be connected")
+	}
+	c, _ := uf.Find("c")
+	a, _ := uf.Find("a")
+	e, ok := uf.Find("e")
+	if c != a || e != "e" || !ok {
+		t.Errorf("Components should be identified by one of their keys")
+	}
+
+	members := uf.Members("d")
+	sort.Strings(members)
+	if len(members) != 4 || members[0] != "a" || members[3] != "d" {
+		t.Errorf("Members of d should be a, b, c and d, got %v", members)
+	}
+
+	comps := uf.Components()
+	if len(comps) != 2 || len(comps[0]) != 4 || comps[1][0] != "e" {
+		t.Errorf("Unexpected components %v", comps)
+	}
+
+	uf.Union("f", "g")
+	if !uf.Connected("f", "g") || uf.Count() != 3 {
+		t.Errorf("Unseen keys should be added by Union, count=%d", uf.Count())
+	}
+}
+
+func TestKeyedUFQueriesDontAddKeys(t *testing.T) {
+	uf := BuildKeyedUF[string]()
+	uf.Union("a", "b")
+
+	if _, ok := uf.Find("x"); ok {
+		t.Errorf("Should not find unseen key x")
+	}
+	if uf.Connected("x", "x") || uf.Connected("a", "x") {
+		t.Errorf("Unseen key x shouldn't be connected to anything")
+	}
+	if members := uf.Members("x"); members != nil {
+		t.Errorf("Unseen key x shouldn't have members, got %v", members)
+	}
+	if uf.Count() != 1 || len(uf.Components()) != 1 {
+		t.Errorf("Queries shouldn't add keys, count=%d", uf.Count())
+	}
+}
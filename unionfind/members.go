@@ -0,0 +1,47 @@
+package unionfind
+
+// ring links every entry to another entry of its component, making a circular
+// list of the members of each component.
+type ring []int
+
+func newRing(n int) ring {
+	r := make(ring, n)
+	for i := range r {
+		r[i] = i
+	}
+	return r
+}
+
+// splice joins the lists of i and j, which must be in different components.
+// Splicing the same i and j again splits them back apart.
+func (r ring) splice(i, j int) {
+	r[i], r[j] = r[j], r[i]
+}
+
+// members lists the entries in the same list as p, starting with p.
+func (r ring) members(p int) []int {
+	m := []int{p}
+	for q := r[p]; q != p; q = r[q] {
+		m = append(m, q)
+	}
+	return m
+}
+
+// components groups the n entries by the component find tells they are in.
+// Components are ordered by their smallest entry, and list their entries in
+// increasing order.
+func components(n, count int, find func(int) int) [][]int {
+	index := make(map[int]int, count)
+	comps := make([][]int, 0, count)
+	for p := 0; p < n; p++ {
+		root := find(p)
+		c, ok := index[root]
+		if !ok {
+			c = len(comps)
+			index[root] = c
+			comps = append(comps, nil)
+		}
+		comps[c] = append(comps[c], p)
+	}
+	return comps
+}
@@ -0,0 +1,91 @@
+package unionfind
+
+// RollbackUF is a weighted quick union find whose unions can be undone, most
+// recent first, such as for offline dynamic connectivity.  It doesn't compress
+// paths, since that couldn't be undone, so Find takes O(log n).
+type RollbackUF struct {
+	id      []int
+	sz      []int
+	next    ring
+	count   int
+	history []int // the roots that were linked under another, in order
+}
+
+// BuildRollbackUF initialize n sites with integer names (0 to N-1)
+func BuildRollbackUF(n int) RollbackUF {
+	uf := RollbackUF{
+		id:    make([]int, n),
+		sz:    make([]int, n),
+		next:  newRing(n),
+		count: n,
+	}
+
+	for i := 0; i < n; i++ {
+		uf.id[i] = i
+		uf.sz[i] = 1
+	}
+	return uf
+}
+
+// Union adds a connection between p and q
+func (u *RollbackUF) Union(p, q int) {
+	i := u.Find(p)
+	j := u.Find(q)
+
+	if i == j {
+		return
+	}
+
+	if u.sz[i] < u.sz[j] {
+		i, j = j, i
+	}
+	u.id[j] = i
+	u.sz[i] += u.sz[j]
+	u.next.splice(i, j)
+	u.count--
+	u.history = append(u.history, j)
+}
+
+// Find tells the component identifier for p (0 to N-1)
+func (u *RollbackUF) Find(p int) int {
+	for p != u.id[p] {
+		p = u.id[p]
+	}
+	return p
+}
+
+// Connected is true if p and q are in the same component
+func (u *RollbackUF) Connected(p, q int) bool { return u.Find(p) == u.Find(q) }
+
+// Count tells the number of components
+func (u *RollbackUF) Count() int { return u.count }
+
+// Members lists the entries in the same component as p, in no particular
+// order.  This takes time proportional to the size of the component.
+func (u *RollbackUF) Members(p int) []int { return u.next.members(p) }
+
+// Components lists the entries of every component
+func (u *RollbackUF) Components() [][]int {
+	return components(len(u.id), u.count, u.Find)
+}
+
+// Snapshot tells the state of the union find, to later Rollback to it.
+// Unions that didn't connect new components are not part of the state.
+func (u *RollbackUF) Snapshot() int { return len(u.history) }
+
+// Rollback undoes every union made since snapshot was taken.  Snapshots taken
+// after snapshot are no longer valid once this returns.
+func (u *RollbackUF) Rollback(snapshot int) {
+	if snapshot < 0 || snapshot > len(u.history) {
+		panic("unionfind: rollback to an invalid snapshot")
+	}
+	for len(u.history) > snapshot {
+		j := u.history[len(u.history)-1]
+		u.history = u.history[:len(u.history)-1]
+		i := u.id[j]
+		u.id[j] = j
+		u.sz[i] -= u.sz[j]
+		u.next.splice(i, j)
+		u.count++
+	}
+}
@@ -58,3 +58,21 @@ func (u *UF) Connected(p, q int) bool { return u.Find(p) == u.Find(q) }
 
 // Count tells the number of components
 func (u *UF) Count() int { return u.count }
+
+// Members lists the entries in the same component as p, starting with p.
+// UF doesn't link the members of its components, to stay small, so this
+// looks at every entry and takes time proportional to N log N.  CompressedUF
+// and RollbackUF list members in time proportional to the component size.
+func (u *UF) Members(p int) []int {
+	root := u.Find(p)
+	m := []int{p}
+	for q := range u.id {
+		if q != p && u.Find(q) == root {
+			m = append(m, q)
+		}
+	}
+	return m
+}
+
+// Components lists the entries of every component
+func (u *UF) Components() [][]int { return components(len(u.id), u.count, u.Find) }
@@ -1,6 +1,7 @@
 package unionfind
 
 import (
+	"math/rand"
 	"testing"
 )
 
@@ -55,3 +56,140 @@ func TestUnionFindMatchesKnownModel(t *testing.T) {
 		}
 	}
 }
+
+// unionFind is what every union find in this package can do.
+type unionFind interface {
+	Union(p, q int)
+	Find(p int) int
+	Connected(p, q int) bool
+	Count() int
+	Members(p int) []int
+	Components() [][]int
+}
+
+var unionFinds = map[string]func(n int) unionFind{
+	"UF":           func(n int) unionFind { uf := BuildUF(n); return &uf },
+	"CompressedUF": func(n int) unionFind { uf := BuildCompressedUF(n); return &uf },
+	"RollbackUF":   func(n int) unionFind { uf := BuildRollbackUF(n); return &uf },
+}
+
+// naiveUF labels every entry with its component, relabeling a whole component
+// on every union.
+type naiveUF []int
+
+func (n naiveUF) union(p, q int) {
+	from, to := n[p], n[q]
+	for i := range n {
+		if n[i] == from {
+			n[i] = to
+		}
+	}
+}
+
+func (n naiveUF) components() [][]int {
+	var comps [][]int
+	index := make(map[int]int)
+	for p, label := range n {
+		c, ok := index[label]
+		if !ok {
+			c = len(comps)
+			index[label] = c
+			comps = append(comps, nil)
+		}
+		comps[c] = append(comps[c], p)
+	}
+	return comps
+}
+
+func checkAgainstNaive(t *testing.T, name string, uf unionFind, naive naiveUF) {
+	want := naive.components()
+	if uf.Count() != len(want) {
+		t.Fatalf("%s: should have %d components, but was %d", name, len(want), uf.Count())
+	}
+	got := uf.Components()
+	if len(got) != len(want) {
+		t.Fatalf("%s: should list %d components, but was %d", name, len(want), len(got))
+	}
+	for c := range want {
+		if len(got[c]) != len(want[c]) {
+			t.Fatalf("%s: component %d should be %v, but was %v", name, c, want[c], got[c])
+		}
+		for i := range want[c] {
+			if got[c][i] != want[c][i] {
+				t.Fatalf("%s: component %d should be %v, but was %v", name, c, want[c], got[c])
+			}
+		}
+	}
+	for p := range naive {
+		members := uf.Members(p)
+		if members[0] != p {
+			t.Errorf("%s: members of %d should start with it, but were %v", name, p, members)
+		}
+		seen := make(map[int]bool)
+		for _, q := range members {
+			if seen[q] || naive[q] != naive[p] {
+				t.Fatalf("%s: members of %d are wrong, got %v", name, p, members)
+			}
+			seen[q] = true
+		}
+		if !uf.Connected(p, members[len(members)-1]) {
+			t.Errorf("%s: %d should be connected to its members %v", name, p, members)
+		}
+	}
+}
+
+func TestUnionFindsMatchNaive(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	for name, build := range unionFinds {
+		n := 50
+		uf, naive := build(n), make(naiveUF, n)
+		for i := range naive {
+			naive[i] = i
+		}
+		for i := 0; i < 60; i++ {
+			p, q := r.Intn(n), r.Intn(n)
+			uf.Union(p, q)
+			naive.union(p, q)
+			if !uf.Connected(p, q) {
+				t.Fatalf("%s: union (%d,%d) was not recorded", name, p, q)
+			}
+			checkAgainstNaive(t, name, uf, naive)
+		}
+	}
+}
+
+func TestRollbackUF(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	n := 30
+	uf := BuildRollbackUF(n)
+
+	var (
+		snapshots []int
+		naives    []naiveUF
+	)
+	naive := make(naiveUF, n)
+	for i := range naive {
+		naive[i] = i
+	}
+	for round := 0; round < 10; round++ {
+		snapshots = append(snapshots, uf.Snapshot())
+		naives = append(naives, append(naiveUF(nil), naive...))
+		for i := 0; i < 5; i++ {
+			p, q := r.Intn(n), r.Intn(n)
+			uf.Union(p, q)
+			naive.union(p, q)
+		}
+		checkAgainstNaive(t, "RollbackUF", &uf, naive)
+	}
+
+	for len(snapshots) != 0 {
+		last := len(snapshots) - 1
+		uf.Rollback(snapshots[last])
+		naive = naives[last]
+		snapshots, naives = snapshots[:last], naives[:last]
+		checkAgainstNaive(t, "RollbackUF", &uf, naive)
+	}
+	if uf.Count() != n {
+		t.Errorf("Should be back to %d components, but was %d", n, uf.Count())
+	}
+}
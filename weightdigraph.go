@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// EdgeWeightedDigraph is a directed graph with weighted edges.
+type EdgeWeightedDigraph struct {
+	adj [][]DirectedEdge
+	e   int
+}
+
+// NewEdgeWeightedDigraph creates an empty digraph with v vertices
+func NewEdgeWeightedDigraph(v int) EdgeWeightedDigraph {
+	return EdgeWeightedDigraph{
+		adj: make([][]DirectedEdge, v),
+		e:   0,
+	}
+}
+
+// ReadWeightDigraph constructs a weighted digraph from the io.Reader
+// expecting to find data formed such as:
+//   v
+//   e
+//   a b w0
+//   c d w1
+//   ...
+//   y z wN
+// where `v` is the vertex count, `e` the number of edges and `a`, `b`, `c`,
+// `d`, ..., `y` and `z` are edges from `a` to `b`, `c` to `d`, ..., and
+// `y` to `z` respectively, and `wN` is the weight of that edge.
+func ReadWeightDigraph(input io.Reader) (EdgeWeightedDigraph, error) {
+	scan := newWeighGraphScanner(input)
+
+	v, err := scan.NextInt()
+	if err != nil {
+		return EdgeWeightedDigraph{}, fmt.Errorf("failed reading vertex count, %v", err)
+	}
+
+	g := NewEdgeWeightedDigraph(v)
+
+	e, err := scan.NextInt()
+	if err != nil {
+		return EdgeWeightedDigraph{}, fmt.Errorf("failed reading edge count, %v", err)
+	}
+
+	for i := 0; i < e; i++ {
+		from, to, weight, err := scan.NextEdge()
+		if err != nil {
+			return g, fmt.Errorf("failed at edge line=%d, %v", i, err)
+		}
+		g.AddEdge(NewDirectedEdge(from, to, weight))
+	}
+
+	return g, nil
+}
+
+// AddEdge adds weighted edge e to this digraph, going out of e.From()
+func (wd *EdgeWeightedDigraph) AddEdge(e DirectedEdge) {
+	wd.adj[e.from] = append(wd.adj[e.from], e)
+	wd.e++
+}
+
+// Adj gives the edges pointing out of v
+func (wd *EdgeWeightedDigraph) Adj(v int) []DirectedEdge {
+	return wd.adj[v]
+}
+
+// Edges gives all the edges in this digraph
+func (wd *EdgeWeightedDigraph) Edges() []DirectedEdge {
+	var edges []DirectedEdge
+	for v := 0; v < wd.V(); v++ {
+		edges = append(edges, wd.Adj(v)...)
+	}
+	return edges
+}
+
+// V is the number of vertices
+func (wd *EdgeWeightedDigraph) V() int {
+	return len(wd.adj)
+}
+
+// E is the number of edges
+func (wd *EdgeWeightedDigraph) E() int {
+	return wd.e
+}
+
+// GoString represents this weighted digraph
+func (wd *EdgeWeightedDigraph) GoString() string {
+	var output bytes.Buffer
+
+	do := func(n int, err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	for v := 0; v < wd.V(); v++ {
+		for _, w := range wd.Adj(v) {
+			do(output.WriteString(w.GoString()))
+			do(output.WriteRune('\n'))
+		}
+	}
+	return output.String()
+}
+
+// DirectedEdge is a weighted edge in a weighted digraph
+type DirectedEdge struct {
+	weight float64
+	from   int
+	to     int
+}
+
+// NewDirectedEdge creates a weighted edge from v to w, to be used by an
+// EdgeWeightedDigraph
+func NewDirectedEdge(v, w int, weight float64) DirectedEdge {
+	return DirectedEdge{weight: weight, from: v, to: w}
+}
+
+// Less tells if this edge is less than the other edge
+func (e *DirectedEdge) Less(other DirectedEdge) bool {
+	return e.weight < other.weight
+}
+
+// From is the tail vertex of this edge
+func (e *DirectedEdge) From() int {
+	return e.from
+}
+
+// To is the head vertex of this edge
+func (e *DirectedEdge) To() int {
+	return e.to
+}
+
+// Weight tells the weight of this edge
+func (e *DirectedEdge) Weight() float64 {
+	return e.weight
+}
+
+// GoString represents this edge in a directed, weighted fashion
+func (e *DirectedEdge) GoString() string {
+	return fmt.Sprintf("%d->%d %.5f", e.from, e.to, e.weight)
+}
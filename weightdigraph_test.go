@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+const tinyEWD = `8
+15
+4 5 0.35
+5 4 0.35
+4 7 0.37
+5 7 0.28
+7 5 0.28
+5 1 0.32
+0 4 0.38
+0 2 0.26
+7 3 0.39
+1 3 0.29
+2 7 0.34
+6 2 0.40
+3 6 0.52
+6 0 0.58
+6 4 0.93
+`
+
+func TestCanCreateEdgeWeightedDigraph(t *testing.T) {
+	for v := 1; v < 100; v++ {
+		wd := NewEdgeWeightedDigraph(v)
+		wd.AddEdge(NewDirectedEdge(0, v-1, float64(v)))
+		wd.GoString()
+		if wd.V() != v {
+			t.Errorf("Expected wd to have %d vertices bu had %d", v, wd.V())
+		}
+	}
+}
+
+func TestEdgeWeightedDigraphIsDirected(t *testing.T) {
+	wd := NewEdgeWeightedDigraph(4)
+	wd.AddEdge(NewDirectedEdge(0, 1, 0.1))
+	wd.AddEdge(NewDirectedEdge(0, 2, 0.2))
+	wd.AddEdge(NewDirectedEdge(0, 3, 0.3))
+	wd.AddEdge(NewDirectedEdge(2, 3, 0.4))
+
+	if wd.E() != 4 {
+		t.Errorf("Expected %d edges but wd.E()=%d", 4, wd.E())
+	}
+
+	if len(wd.Adj(0)) != 3 {
+		t.Errorf("Expected 3 adjacent to 0, but was %v", wd.Adj(0))
+	}
+
+	if len(wd.Adj(3)) != 0 {
+		t.Errorf("Expected none adjacent to 3, but was %v", wd.Adj(3))
+	}
+
+	if len(wd.Edges()) != 4 {
+		t.Errorf("Expected 4 edges, but was %v", wd.Edges())
+	}
+
+	for _, e := range wd.Adj(0) {
+		if e.From() != 0 {
+			t.Errorf("Edge %#v should come from 0", e)
+		}
+	}
+}
+
+func TestEdgeWeightedDigraphFromReader(t *testing.T) {
+	wd, err := ReadWeightDigraph(strings.NewReader(tinyEWD))
+	if err != nil {
+		t.Fatalf("Couldn't read digraph, %v", err)
+	}
+
+	if wd.V() != 8 {
+		t.Errorf("Vertex count, want %d got %d", 8, wd.V())
+	}
+
+	if wd.E() != 15 {
+		t.Errorf("Edge count, want %d got %d", 15, wd.E())
+	}
+
+	for _, e := range wd.Adj(6) {
+		switch e.To() {
+		case 2, 0, 4:
+			continue
+		}
+		t.Errorf("6 should not point to %d", e.To())
+	}
+}
+
+func TestEdgeWeightedDigraphFromBadReader(t *testing.T) {
+	_, err := ReadWeightDigraph(strings.NewReader("8\n15\n4 5"))
+	if err == nil {
+		t.Errorf("Should have failed reading a truncated digraph")
+	}
+}